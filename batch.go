@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// BatchOptions change how a batch of objects is validated (see ValidateBatch and ValidateStream)
+type BatchOptions struct {
+	// Options are the options each object is validated with (see IsValidOptions)
+	Options Options
+
+	// Workers is the number of goroutines validating the objects, runtime.GOMAXPROCS(0) by default
+	Workers int
+
+	// MaxInvalid stops after that many invalid objects, 0 for no limit
+	MaxInvalid int
+}
+
+// BatchResult holds the errors of an invalid object of a batch
+type BatchResult struct {
+	// Index is the index of the object in the batch
+	Index int
+
+	// Errors are the errors of the object
+	Errors []ValidationError
+}
+
+// batchJob is an object of a batch to validate, through a pointer to it
+type batchJob struct {
+	index int
+	value reflect.Value
+}
+
+// ValidateBatch validates the structs (or pointers to structs) of the slice with a pool of workers
+// using DefaultMap, see ValidateMapBatch
+func ValidateBatch[T any](ctx context.Context, values []T, options BatchOptions) ([]BatchResult, error) {
+	return ValidateMapBatch(ctx, &DefaultMap, values, options)
+}
+
+// ValidateMapBatch validates the structs (or pointers to structs) of the slice with a pool of workers
+// using the given map, returning the results of the invalid ones in the order of the slice. Each type
+// is compiled once, as IsValid compiles it, and shared by the workers. With MaxInvalid the results are
+// the first invalid objects of the slice. When the context is done, validating stops and the results
+// found so far are returned with the error of the context.
+func ValidateMapBatch[T any](ctx context.Context, m *Map, values []T, options BatchOptions) ([]BatchResult, error) {
+	next := 0
+	return m.validateBatch(ctx, options, func(<-chan struct{}) (reflect.Value, bool) {
+		if next == len(values) {
+			return reflect.Value{}, false
+		}
+		next++
+		return reflect.ValueOf(&values[next-1]), true
+	})
+}
+
+// ValidateStream validates the structs (or pointers to structs) received from the channel with a
+// pool of workers using DefaultMap, see ValidateMapStream
+func ValidateStream[T any](ctx context.Context, values <-chan T, options BatchOptions) ([]BatchResult, error) {
+	return ValidateMapStream(ctx, &DefaultMap, values, options)
+}
+
+// ValidateMapStream validates the structs (or pointers to structs) received from the channel until it
+// is closed, as ValidateMapBatch validates a slice, indexing them in the order they are received. The
+// valid objects are not kept, so streams larger than memory can be validated.
+func ValidateMapStream[T any](ctx context.Context, m *Map, values <-chan T, options BatchOptions,
+) ([]BatchResult, error) {
+	return m.validateBatch(ctx, options, func(done <-chan struct{}) (reflect.Value, bool) {
+		select {
+		case value, ok := <-values:
+			if !ok {
+				return reflect.Value{}, false
+			}
+			return reflect.ValueOf(&value), true
+		case <-done:
+			return reflect.Value{}, false
+		}
+	})
+}
+
+// validateBatch validates the objects next returns (until it returns false, or stop is closed) with a
+// pool of workers. The objects are dispatched in order and every dispatched object is validated, so
+// when validating stops at MaxInvalid the first invalid objects of the batch have all been found.
+func (m *Map) validateBatch(ctx context.Context, options BatchOptions,
+	next func(stop <-chan struct{}) (reflect.Value, bool),
+) ([]BatchResult, error) {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	mode := options.Options.mode()
+
+	// stop is done when the context is, or when enough invalid objects were found
+	stop, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Dispatch the objects in order
+	jobs := make(chan batchJob, workers)
+	go func() {
+		defer close(jobs)
+		for index := 0; stop.Err() == nil; index++ {
+			value, ok := next(stop.Done())
+			if !ok {
+				return
+			}
+			select {
+			case jobs <- batchJob{index: index, value: value}:
+			case <-stop.Done():
+				return
+			}
+		}
+	}()
+
+	// Validate the objects, skipping the rest once the context is done
+	results := make(chan BatchResult, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if _, errors := m.validateObject(job.value, mode); len(errors) > 0 {
+					results <- BatchResult{Index: job.index, Errors: errors}
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Collect the invalid objects, stopping the dispatch at the maximum
+	var invalid []BatchResult
+	for result := range results {
+		invalid = append(invalid, result)
+		if options.MaxInvalid > 0 && len(invalid) >= options.MaxInvalid {
+			cancel()
+		}
+	}
+
+	sort.Slice(invalid, func(i, j int) bool { return invalid[i].Index < invalid[j].Index })
+	if options.MaxInvalid > 0 && len(invalid) > options.MaxInvalid {
+		invalid = invalid[:options.MaxInvalid]
+	}
+	return invalid, ctx.Err()
+}
@@ -0,0 +1,191 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchRow is a row of an import, invalid when its name is empty or its age is negative
+type batchRow struct {
+	Name string `validation:"required counted"`
+	Age  int    `validation:"min=0"`
+}
+
+// batchRows creates rows where every seventh row is invalid, and every twenty-first twice
+func batchRows(count int) []batchRow {
+	rows := make([]batchRow, count)
+	for i := range rows {
+		rows[i] = batchRow{Name: "row " + strconv.Itoa(i), Age: i}
+		if i%7 == 0 {
+			rows[i].Name = ""
+		}
+		if i%21 == 0 {
+			rows[i].Age = -1
+		}
+	}
+	return rows
+}
+
+// countedValidation is a validation counting how many times it is built, which never fails
+type countedValidation struct {
+	Validation
+}
+
+// Validate accepts any value
+func (c *countedValidation) Validate(interface{}, reflect.Value) *ValidationError {
+	return nil
+}
+
+// batchMap creates a map with the counted validation, counting how many times it is built
+func batchMap(builds *int32) *Map {
+	m := NewMap()
+	m.AddValidation("counted", func(string, reflect.Kind) (Interface, error) {
+		atomic.AddInt32(builds, 1)
+		return &countedValidation{}, nil
+	})
+	return m
+}
+
+// sequentialResults validates the rows one by one, as a batch reports them
+func sequentialResults(m *Map, rows []batchRow) []BatchResult {
+	var results []BatchResult
+	for i := range rows {
+		if _, errs := m.IsValid(rows[i]); len(errs) > 0 {
+			results = append(results, BatchResult{Index: i, Errors: errs})
+		}
+	}
+	return results
+}
+
+// TestValidateMapBatch tests validating a slice with workers, with the results in the order of the slice
+func TestValidateMapBatch(t *testing.T) {
+	var builds int32
+	m := batchMap(&builds)
+	rows := batchRows(1000)
+
+	results, err := ValidateMapBatch(context.Background(), m, rows, BatchOptions{Workers: 8})
+	require.NoError(t, err)
+	require.Len(t, results, 143)
+	assert.Equal(t, sequentialResults(m, rows), results)
+	assert.Equal(t, BatchResult{Index: 21, Errors: []ValidationError{
+		{Key: "Name", Message: "is required"},
+		{Key: "Age", Message: "must be greater than or equal to 0"},
+	}}, BatchResult{Index: results[3].Index, Errors: withoutDetails(results[3].Errors)})
+
+	// The type is compiled once for all the workers
+	assert.Equal(t, int32(1), atomic.LoadInt32(&builds))
+
+	// The same results with the default number of workers, or with one
+	for _, workers := range []int{0, 1} {
+		same, err := ValidateMapBatch(context.Background(), m, rows, BatchOptions{Workers: workers})
+		require.NoError(t, err)
+		assert.Equal(t, results, same)
+	}
+
+	// Pointers, with nil pointers reported as ErrNotStruct
+	pointers := []*batchRow{&rows[1], nil, &rows[7]}
+	pointerResults, err := ValidateMapBatch(context.Background(), m, pointers, BatchOptions{})
+	require.NoError(t, err)
+	require.Len(t, pointerResults, 2)
+	assert.Equal(t, 1, pointerResults[0].Index)
+	require.ErrorIs(t, ValidationErrors(pointerResults[0].Errors), ErrNotStruct)
+	assert.Equal(t, 2, pointerResults[1].Index)
+
+	// Nothing to validate
+	empty, err := ValidateMapBatch(context.Background(), m, []batchRow(nil), BatchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+// TestValidateMapBatchMaxInvalid tests stopping after the first invalid objects of the batch
+func TestValidateMapBatchMaxInvalid(t *testing.T) {
+	var builds int32
+	m := batchMap(&builds)
+	rows := batchRows(10000)
+	all := sequentialResults(m, rows)
+
+	for _, workers := range []int{1, 4, 16} {
+		results, err := ValidateMapBatch(context.Background(), m, rows, BatchOptions{Workers: workers, MaxInvalid: 5})
+		require.NoError(t, err)
+		assert.Equal(t, all[:5], results, "%d workers", workers)
+	}
+
+	// The options apply to each object
+	results, err := ValidateMapBatch(context.Background(), m, rows[:22], BatchOptions{Options: Options{FailFast: true}})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, []ValidationError{{Key: "Name", Message: "is required"}}, withoutDetails(results[3].Errors))
+}
+
+// TestValidateMapBatchCanceled tests stopping when the context is done
+func TestValidateMapBatchCanceled(t *testing.T) {
+	var builds int32
+	m := batchMap(&builds)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := ValidateMapBatch(ctx, m, batchRows(10000), BatchOptions{Workers: 4})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, results)
+
+	// A stream waiting for values stops too
+	values := make(chan batchRow)
+	_, err = ValidateMapStream(ctx, m, values, BatchOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestValidateMapStream tests validating the objects of a channel, indexed in the order they are received
+func TestValidateMapStream(t *testing.T) {
+	var builds int32
+	m := batchMap(&builds)
+	rows := batchRows(1000)
+
+	values := make(chan batchRow)
+	go func() {
+		defer close(values)
+		for _, row := range rows {
+			values <- row
+		}
+	}()
+	results, err := ValidateMapStream(context.Background(), m, values, BatchOptions{Workers: 4})
+	require.NoError(t, err)
+	assert.Equal(t, sequentialResults(m, rows), results)
+
+	// Validating stops at the maximum, leaving the rest of the values in the channel
+	values = make(chan batchRow, len(rows))
+	for _, row := range rows {
+		values <- row
+	}
+	close(values)
+	results, err = ValidateMapStream(context.Background(), m, values, BatchOptions{MaxInvalid: 2})
+	require.NoError(t, err)
+	assert.Equal(t, sequentialResults(m, rows)[:2], results)
+}
+
+// TestValidateBatch tests validating a batch using DefaultMap
+func TestValidateBatch(t *testing.T) {
+	InitValidations()
+	type row struct {
+		Name string `validation:"required"`
+	}
+
+	results, err := ValidateBatch(context.Background(), []row{{Name: "a"}, {}}, BatchOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Index)
+
+	values := make(chan row, 2)
+	values <- row{}
+	values <- row{Name: "b"}
+	close(values)
+	results, err = ValidateStream(context.Background(), values, BatchOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].Index)
+}
@@ -0,0 +1,24 @@
+/*
+Package main is validate-gen, which generates Validate methods for structs from their validation tags,
+running the same validations as validate.IsValid without reflection:
+
+	//go:generate go run github.com/mrz1836/go-validate/cmd/validate-gen -type Customer,Order -test
+
+See the gen package for the options and for generating custom rules.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mrz1836/go-validate/gen"
+)
+
+// main generates the validations of the package in the directory given in the arguments
+func main() {
+	if err := gen.NewGenerator().Main(os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "validate-gen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,312 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// comparison is the relation a field must have to the field it is compared to
+type comparison int
+
+// Comparisons between two fields
+const (
+	equal comparison = iota
+	notEqual
+	greater
+	greaterOrEqual
+	less
+	lessOrEqual
+)
+
+// timeType is used to compare time.Time values by their instant
+var timeType = reflect.TypeOf(time.Time{}) //nolint:gochecknoglobals // Type used for comparisons
+
+// holds determines if the comparison holds for the result of compareValues
+func (c comparison) holds(result int) bool {
+	switch c {
+	case equal:
+		return result == 0
+	case notEqual:
+		return result != 0
+	case greater:
+		return result > 0
+	case greaterOrEqual:
+		return result >= 0
+	case less:
+		return result < 0
+	case lessOrEqual:
+		return result <= 0
+	}
+	return false
+}
+
+// ordered determines if the comparison needs ordered values (not only equality)
+func (c comparison) ordered() bool {
+	return c != equal && c != notEqual
+}
+
+// String returns the comparison as used in error messages (after "must")
+func (c comparison) String() string {
+	switch c {
+	case equal:
+		return "be equal to"
+	case notEqual:
+		return "not be equal to"
+	case greater:
+		return "be greater than"
+	case greaterOrEqual:
+		return "be greater than or equal to"
+	case less:
+		return "be less than"
+	case lessOrEqual:
+		return "be less than or equal to"
+	}
+	return ""
+}
+
+// fieldComparisonValidation type used for comparing the value to another field of the struct
+type fieldComparisonValidation struct {
+	// Validation is the validation interface
+	Validation
+
+	// fieldNamer names the target field in the errors
+	fieldNamer
+
+	// targetFieldName is the name (or dotted path) of the field to compare to
+	targetFieldName string
+
+	// comparison is the relation the value must have to the target field
+	comparison comparison
+}
+
+// Validate is for the fieldComparisonValidation type and will compare the value to the target field
+func (f *fieldComparisonValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return f.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the fieldComparisonValidation type and will compare the value without boxing it
+func (f *fieldComparisonValidation) ValidateValue(value, obj reflect.Value) *ValidationError {
+	target, found := lookupField(obj, f.targetFieldName)
+	if !found {
+		return &ValidationError{
+			Key:     f.FieldName(),
+			Message: "cannot be compared to the unknown field " + f.targetFieldName,
+			Code:    "unknown_field",
+			Params:  []string{f.targetFieldName},
+		}
+	}
+
+	// There is nothing to compare to when the target field is a nil pointer
+	target, absent := indirectValue(target)
+	if absent {
+		return nil
+	}
+
+	// Values that are not ordered can still be compared for equality
+	result, ok := compareValues(value, target)
+	if !ok && !f.comparison.ordered() && value.IsValid() && value.Type() == target.Type() &&
+		value.CanInterface() && target.CanInterface() {
+		result, ok = 1, true
+		if reflect.DeepEqual(value.Interface(), target.Interface()) {
+			result = 0
+		}
+	}
+	if !ok {
+		targetName := f.fieldPath(obj, f.targetFieldName)
+		return &ValidationError{
+			Key:     f.FieldName(),
+			Message: "cannot be compared to the field " + targetName,
+			Code:    "not_comparable",
+			Params:  []string{targetName},
+		}
+	}
+
+	if !f.comparison.holds(result) {
+		targetName := f.fieldPath(obj, f.targetFieldName)
+		return &ValidationError{
+			Key:     f.FieldName(),
+			Message: "must " + f.comparison.String() + " " + targetName,
+			Params:  []string{targetName},
+		}
+	}
+
+	return nil
+}
+
+// lookupField finds a field of the struct by name, or by a dotted path through nested
+// structs (e.g. "Range.Start"). Pointers along the path are followed, and a nil pointer
+// is returned as is so the caller can treat it as absent. Maps with string keys (e.g. see
+// Schema) are looked up by key, where a missing key is the zero value (absent for interfaces).
+func lookupField(obj reflect.Value, path string) (reflect.Value, bool) {
+	field := obj
+	for more := true; more; {
+		var name string
+		name, path, more = strings.Cut(path, ".")
+		field, _ = indirectValue(field)
+		switch {
+		case field.Kind() == reflect.Struct:
+			if field = field.FieldByName(name); !field.IsValid() {
+				return reflect.Value{}, false
+			}
+		case field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String:
+			value := mapValue(field, name)
+			if !value.IsValid() {
+				value = reflect.Zero(field.Type().Elem())
+			}
+			field = value
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return field, true
+}
+
+// compareValues compares two ordered values, returning a negative number when a is less than b,
+// zero when they are equal and a positive number when a is greater than b. Integers, unsigned
+// integers and floats can be compared to each other, strings to strings and time.Time to
+// time.Time. False is returned when the values cannot be compared, which includes times read
+// from unexported fields.
+func compareValues(a, b reflect.Value) (int, bool) {
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	switch {
+	case isNumericKind(a.Kind()) && isNumericKind(b.Kind()):
+		return compareNumbers(a, b), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case a.Type() == timeType && b.Type() == timeType && a.CanInterface() && b.CanInterface():
+		aTime, bTime := timeValue(a), timeValue(b)
+		switch {
+		case aTime.Before(bTime):
+			return -1, true
+		case aTime.After(bTime):
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// timeValue gets the time.Time of a value, through its address when it has one so it is not boxed
+func timeValue(value reflect.Value) time.Time {
+	if value.CanAddr() {
+		return *value.Addr().Interface().(*time.Time)
+	}
+	return value.Interface().(time.Time)
+}
+
+// compareNumbers compares two values of numeric kinds, mixing signed and unsigned integers exactly
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case isFloatKind(a.Kind()) || isFloatKind(b.Kind()):
+		return compareOrdered(numberAsFloat(a), numberAsFloat(b))
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compareOrdered(a.Uint(), b.Uint())
+	case isUintKind(a.Kind()):
+		// A negative signed integer is less than any unsigned integer
+		if b.Int() < 0 || a.Uint() > math.MaxInt64 {
+			return 1
+		}
+		return compareOrdered(int64(a.Uint()), b.Int())
+	case isUintKind(b.Kind()):
+		return -compareNumbers(b, a)
+	default:
+		return compareOrdered(a.Int(), b.Int())
+	}
+}
+
+// compareOrdered compares two ordered values
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// numberAsFloat converts a value of any numeric kind to a float64
+func numberAsFloat(value reflect.Value) float64 {
+	switch {
+	case isFloatKind(value.Kind()):
+		return value.Float()
+	case isUintKind(value.Kind()):
+		return float64(value.Uint())
+	default:
+		return float64(value.Int())
+	}
+}
+
+// isNumericKind determines if the kind is an integer, unsigned integer or float
+func isNumericKind(kind reflect.Kind) bool {
+	return isFloatKind(kind) || isUintKind(kind) ||
+		kind == reflect.Int || kind == reflect.Int8 || kind == reflect.Int16 || kind == reflect.Int32 || kind == reflect.Int64
+}
+
+// isUintKind determines if the kind is an unsigned integer
+func isUintKind(kind reflect.Kind) bool {
+	return kind == reflect.Uint || kind == reflect.Uint8 || kind == reflect.Uint16 || kind == reflect.Uint32 || kind == reflect.Uint64
+}
+
+// isFloatKind determines if the kind is a float
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// fieldComparisonBuilder creates a builder for the comparison, taking the target field name
+// as the only parameter. Ordered comparisons are only allowed for numeric, string and time.Time
+// fields, which is all the builder can check from the kind (struct kinds are checked at runtime).
+func fieldComparisonBuilder(c comparison) RuleBuilder {
+	return func(rule Rule, kind reflect.Kind) (Interface, error) {
+		if len(rule.Params) != 1 || len(rule.Params[0]) == 0 {
+			return nil, ErrFieldNameRequired
+		}
+
+		if c.ordered() && !isNumericKind(kind) && kind != reflect.String && kind != reflect.Struct {
+			return nil, ErrNotOrdered
+		}
+
+		return &fieldComparisonValidation{
+			targetFieldName: rule.Params[0],
+			comparison:      c,
+		}, nil
+	}
+}
+
+var comparisonValidationsOnce sync.Once //nolint:gochecknoglobals // Validation registration synchronization
+
+// RegisterComparisonValidations registers all validations comparing a field to another field
+func RegisterComparisonValidations() {
+	comparisonValidationsOnce.Do(func() {
+		registerComparisonValidations(&DefaultMap)
+	})
+}
+
+// registerComparisonValidations registers all validations comparing a field to another field with the map
+func registerComparisonValidations(m *Map) {
+	// Equal validation is where X must be equal to the field Y
+	m.AddRuleValidation("eq_field", fieldComparisonBuilder(equal))
+
+	// Not equal validation is where X must not be equal to the field Y
+	m.AddRuleValidation("ne_field", fieldComparisonBuilder(notEqual))
+
+	// Greater than validation is where X must be greater than (after) the field Y
+	m.AddRuleValidation("gt_field", fieldComparisonBuilder(greater))
+
+	// Greater than or equal validation is where X cannot be less than the field Y
+	m.AddRuleValidation("gte_field", fieldComparisonBuilder(greaterOrEqual))
+
+	// Less than validation is where X must be less than (before) the field Y
+	m.AddRuleValidation("lt_field", fieldComparisonBuilder(less))
+
+	// Less than or equal validation is where X cannot be greater than the field Y
+	m.AddRuleValidation("lte_field", fieldComparisonBuilder(lessOrEqual))
+}
@@ -0,0 +1,270 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFieldComparisonBuilder tests building the field comparison validations
+func TestFieldComparisonBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		comparison    comparison
+		rule          Rule
+		kind          reflect.Kind
+		expectedError error
+	}{
+		{"valid ordered", greater, Rule{Name: "gt_field", Params: []string{"Start"}}, reflect.Int, nil},
+		{"valid time", less, Rule{Name: "lt_field", Params: []string{"End"}}, reflect.Struct, nil},
+		{"valid equality on any kind", equal, Rule{Name: "eq_field", Params: []string{"Other"}}, reflect.Bool, nil},
+		{"missing field name", equal, Rule{Name: "eq_field"}, reflect.String, ErrFieldNameRequired},
+		{"empty field name", equal, Rule{Name: "eq_field", Params: []string{""}}, reflect.String, ErrFieldNameRequired},
+		{"too many field names", equal, Rule{Name: "eq_field", Params: []string{"A", "B"}}, reflect.String, ErrFieldNameRequired},
+		{"not ordered", greaterOrEqual, Rule{Name: "gte_field", Params: []string{"Other"}}, reflect.Bool, ErrNotOrdered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation, err := fieldComparisonBuilder(tt.comparison)(tt.rule, tt.kind)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, validation)
+		})
+	}
+}
+
+// TestCompareValues tests comparing values of every ordered kind
+func TestCompareValues(t *testing.T) {
+	type Cents int64
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		a        interface{}
+		b        interface{}
+		expected int
+		ok       bool
+	}{
+		{"equal ints", 1, 1, 0, true},
+		{"less int", int8(-1), int64(1), -1, true},
+		{"named int", Cents(10), 5, 1, true},
+		{"uints", uint(2), uint64(1), 1, true},
+		{"uint and negative int", uint(0), -1, 1, true},
+		{"negative int and uint", -1, uint(0), -1, true},
+		{"large uint and int", uint64(math.MaxUint64), math.MaxInt64, 1, true},
+		{"uint and int", uint8(3), 3, 0, true},
+		{"floats", 1.5, float32(2.5), -1, true},
+		{"float and int", 2.5, 2, 1, true},
+		{"strings", "a", "b", -1, true},
+		{"times", now, now.Add(time.Hour), -1, true},
+		{"equal times in different locations", now, now.UTC(), 0, true},
+		{"string and int", "1", 1, 0, false},
+		{"bools", true, false, 0, false},
+		{"invalid", nil, 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := compareValues(reflect.ValueOf(tt.a), reflect.ValueOf(tt.b))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestFieldComparisons tests the field comparison validations on structs
+func TestFieldComparisons(t *testing.T) {
+	type Range struct {
+		Start time.Time
+		End   time.Time `validation:"gt_field=Start"`
+	}
+
+	type Search struct {
+		MinPrice    float64 `validation:"lte_field=MaxPrice"`
+		MaxPrice    float64 `validation:"gte_field=MinPrice"`
+		MinQuantity uint
+		Quantity    int `validation:"gte_field=MinQuantity lt_field=Limit"`
+		Limit       *int64
+		OldPassword string `validation:"ne_field=NewPassword"`
+		NewPassword string
+		Confirm     string `validation:"eq_field=NewPassword"`
+		Active      bool   `validation:"eq_field=Enabled"`
+		Enabled     bool
+		Dates       Range
+		Deadline    time.Time `validation:"omitempty lte_field=Dates.End"`
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := int64(10)
+
+	valid := func() Search {
+		return Search{
+			MinPrice:    10,
+			MaxPrice:    20,
+			MinQuantity: 1,
+			Quantity:    5,
+			Limit:       &limit,
+			OldPassword: "old",
+			NewPassword: "new",
+			Confirm:     "new",
+			Dates:       Range{Start: start, End: start.Add(time.Hour)},
+			Deadline:    start,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		modify         func(search *Search)
+		expectedErrors []string
+	}{
+		{
+			name:   "all valid",
+			modify: func(_ *Search) {},
+		},
+		{
+			name: "equal prices are allowed",
+			modify: func(search *Search) {
+				search.MinPrice = 20
+			},
+		},
+		{
+			name: "nil target is not compared",
+			modify: func(search *Search) {
+				search.Limit = nil
+				search.Quantity = 100
+			},
+		},
+		{
+			name: "min price greater than max price",
+			modify: func(search *Search) {
+				search.MinPrice = 30
+			},
+			expectedErrors: []string{
+				"MinPrice must be less than or equal to MaxPrice",
+				"MaxPrice must be greater than or equal to MinPrice",
+			},
+		},
+		{
+			name: "signed and unsigned fields",
+			modify: func(search *Search) {
+				search.Quantity = -1
+			},
+			expectedErrors: []string{"Quantity must be greater than or equal to MinQuantity"},
+		},
+		{
+			name: "pointer target",
+			modify: func(search *Search) {
+				search.Quantity = 10
+			},
+			expectedErrors: []string{"Quantity must be less than Limit"},
+		},
+		{
+			name: "same password",
+			modify: func(search *Search) {
+				search.OldPassword = "new"
+			},
+			expectedErrors: []string{"OldPassword must not be equal to NewPassword"},
+		},
+		{
+			name: "confirmation differs",
+			modify: func(search *Search) {
+				search.Confirm = "other"
+			},
+			expectedErrors: []string{"Confirm must be equal to NewPassword"},
+		},
+		{
+			name: "equality of bools",
+			modify: func(search *Search) {
+				search.Enabled = true
+			},
+			expectedErrors: []string{"Active must be equal to Enabled"},
+		},
+		{
+			name: "end date before start date in a nested struct",
+			modify: func(search *Search) {
+				search.Dates.End = start.Add(-time.Hour)
+				search.Deadline = time.Time{}
+			},
+			expectedErrors: []string{"Dates.End must be greater than Start"},
+		},
+		{
+			name: "dotted path target",
+			modify: func(search *Search) {
+				search.Deadline = start.Add(2 * time.Hour)
+			},
+			expectedErrors: []string{"Deadline must be less than or equal to Dates.End"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := valid()
+			tt.modify(&search)
+
+			ok, errs := IsValid(search)
+			assert.Equal(t, len(tt.expectedErrors) == 0, ok)
+
+			messages := make([]string, 0, len(errs))
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tt.expectedErrors, messages)
+		})
+	}
+}
+
+// TestFieldComparisonInvalidTargets tests comparing to fields that are missing or of another type
+func TestFieldComparisonInvalidTargets(t *testing.T) {
+	type Model struct {
+		Name    string `validation:"eq_field=Missing"`
+		Age     int    `validation:"gt_field=Name"`
+		Created time.Time
+		Other   struct{ Value int }
+		Ends    time.Time `validation:"gt_field=Other"`
+	}
+
+	_, errs := IsValid(Model{})
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		"Name cannot be compared to the unknown field Missing",
+		"Age cannot be compared to the field Name",
+		"Ends cannot be compared to the field Other",
+	}, messages)
+}
+
+// TestFieldComparisonUnexportedTargets tests comparing to unexported fields that cannot be read as interfaces
+func TestFieldComparisonUnexportedTargets(t *testing.T) {
+	type Period struct{ Days int }
+	type Model struct {
+		start  time.Time
+		period Period
+		Ends   time.Time `validation:"gt_field=start"`
+		Range  Period    `validation:"eq_field=period"`
+	}
+
+	var model Model
+	assert.NotPanics(t, func() { _, _ = IsValid(model) })
+
+	_, errs := IsValid(Model{start: time.Now(), period: Period{Days: 1}})
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		assert.Equal(t, "not_comparable", err.Code)
+		messages = append(messages, err.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		"Ends cannot be compared to the field start",
+		"Range cannot be compared to the field period",
+	}, messages)
+}
@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// CompileError describes a validation tag that could not be compiled into validations
+type CompileError struct {
+	// Struct is the name of the struct type
+	Struct string
+
+	// Field is the name of the field holding the tag (empty when the type itself is invalid)
+	Field string
+
+	// Tag is the fragment of the validation tag that failed
+	Tag string
+
+	// Err is the cause (ErrUnknownValidation, a builder error, etc.)
+	Err error
+}
+
+// newCompileError creates a compile error for the tag fragment of a struct field
+func newCompileError(objectType reflect.Type, field reflect.StructField, tag string, err error) *CompileError {
+	return &CompileError{
+		Struct: objectType.String(),
+		Field:  field.Name,
+		Tag:    tag,
+		Err:    err,
+	}
+}
+
+// Error returns a string of the struct, field, tag and cause
+func (c *CompileError) Error() string {
+	if len(c.Field) == 0 {
+		return c.Struct + ": " + c.Err.Error()
+	}
+	return c.Struct + "." + c.Field + ": invalid validation " + strconv.Quote(c.Tag) + ": " + c.Err.Error()
+}
+
+// Unwrap returns the cause so errors.Is and errors.As can be used
+func (c *CompileError) Unwrap() error {
+	return c.Err
+}
+
+// validationError converts the compile error to a validation error for the struct at the given path
+func (c *CompileError) validationError(prefix string) ValidationError {
+	if len(c.Field) == 0 {
+		return ValidationError{Key: prefix, Message: c.Err.Error(), Err: c}
+	}
+	return ValidationError{
+		Key:     prefix + c.Field,
+		Message: "has an invalid validation " + strconv.Quote(c.Tag) + ": " + c.Err.Error(),
+		Err:     c,
+	}
+}
@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompileErrorError tests the message of a compile error
+func TestCompileErrorError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *CompileError
+		expected string
+	}{
+		{
+			name:     "field error",
+			err:      &CompileError{Struct: "model.Customer", Field: "Age", Tag: "min=abc", Err: ErrInvalidSpecification},
+			expected: `model.Customer.Age: invalid validation "min=abc": ` + ErrInvalidSpecification.Error(),
+		},
+		{
+			name:     "type error",
+			err:      &CompileError{Struct: "int", Err: ErrNotStruct},
+			expected: "int: " + ErrNotStruct.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+// TestCompileErrorUnwrap tests that the cause can be found with errors.Is
+func TestCompileErrorUnwrap(t *testing.T) {
+	var err error = &CompileError{Struct: "model.Customer", Field: "Age", Tag: "foo=1", Err: ErrUnknownValidation}
+	assert.ErrorIs(t, err, ErrUnknownValidation)
+}
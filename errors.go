@@ -4,6 +4,37 @@ import "errors"
 
 // Static error definitions to satisfy err113 linter
 var (
+	// Validation tag errors
+	ErrNotStruct               = errors.New("is not a struct")
+	ErrInvalidSpecification    = errors.New("invalid validation specification")
+	ErrUnknownValidation       = errors.New("unknown validation named")
+	ErrInvalidDive             = errors.New("dive can only be used on slices, arrays and maps")
+	ErrInvalidKeys             = errors.New("keys must directly follow a dive into a map")
+	ErrMissingKeys             = errors.New("endkeys must follow keys")
+	ErrMissingEndKeys          = errors.New("keys must be closed with endkeys")
+	ErrNoParameters            = errors.New("validation does not take parameters")
+	ErrFieldNameRequired       = errors.New("validation requires the name of another field")
+	ErrFieldValuePairsRequired = errors.New("validation requires pairs of field names and values")
+	ErrNotOrdered              = errors.New("field is not of an ordered type (numeric, string or time.Time)")
+	ErrAllowedValuesRequired   = errors.New("validation requires the allowed values")
+	ErrUnknownOption           = errors.New("unknown validation option named")
+	ErrGroupNameRequired       = errors.New("groups option requires group names")
+	ErrMessageRequired         = errors.New("message option requires a message")
+	ErrModifierOptions         = errors.New("dive, keys, endkeys and omitempty do not take options")
+	ErrUnknownField            = errors.New("struct has no field named")
+	ErrNotFieldPointer         = errors.New("is not a pointer to a field of the struct the rules were created from")
+	ErrUnknownSchemaType       = errors.New("unknown schema type")
+	ErrSchemaTypeConflict      = errors.New("only objects have properties and only arrays have items")
+	ErrSchemaDive              = errors.New("schemas use properties and items instead of dive")
+	ErrInvalidSchemaPath       = errors.New("invalid schema path")
+	ErrInvalidJSON             = errors.New("invalid JSON document")
+	ErrInvalidJSONSchema       = errors.New("invalid JSON Schema keyword")
+	ErrUnsupportedJSONSchema   = errors.New("unsupported JSON Schema keyword")
+	ErrJSONSchemaRef           = errors.New("cannot resolve the JSON Schema reference")
+	ErrUnnamedComponent        = errors.New("components must be named struct types")
+	ErrInvalidOpenAPIExtension = errors.New("OpenAPI extension names must start with x-")
+	ErrGeneratedMismatch       = errors.New("generated validations differ from IsValid")
+
 	// Enum validation errors
 	ErrEnumValueNotAllowed = errors.New("value is not allowed")
 
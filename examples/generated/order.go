@@ -0,0 +1,87 @@
+/*
+Package main is an example of generating Validate methods with validate-gen, which run the same
+validations as validate.IsValid without reflection
+*/
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/mrz1836/go-validate"
+)
+
+//go:generate go run github.com/mrz1836/go-validate/cmd/validate-gen -type Order -test
+
+// Address is a nested struct of the order
+type Address struct {
+	Street  string  `validation:"required min_length=3" json:"street"`
+	City    string  `validation:"required" json:"city"`
+	Country string  `validation:"one_of=US,CA,MX" json:"country"`
+	Zip     *string `validation:"omitempty format=regexp:^[0-9]{5}$" json:"zip"`
+}
+
+// Item is an element of the order's items
+type Item struct {
+	SKU      string  `validation:"required format=regexp:^[A-Z]{3}-[0-9]+$" json:"sku"`
+	Quantity int     `validation:"min=1 max=100" json:"quantity"`
+	Price    float64 `validation:"min=0.01" json:"price"`
+}
+
+// Audit is embedded in the order, its fields are reported without a prefix
+type Audit struct {
+	CreatedBy string    `validation:"required" json:"created_by"`
+	CreatedAt time.Time `validation:"required" json:"created_at"`
+}
+
+// Order is a model with nested structs, collections and rules run by their validations
+// (format=email, compare and required_with have no generated code)
+type Order struct {
+	Audit
+	ID           uint64             `validation:"min=1" json:"id"`
+	Email        string             `validation:"required format=email" json:"email"`
+	ConfirmEmail string             `validation:"compare=Email" json:"-"`
+	Billing      *Address           `validation:"required" json:"billing"`
+	Shipping     *Address           `json:"shipping"`
+	Items        []Item             `validation:"required dive" json:"items"`
+	Tags         []string           `validation:"omitempty dive min_length=2 max_length=20" json:"tags"`
+	Discounts    map[string]float32 `validation:"dive keys min_length=3 endkeys min=0 max=1" json:"discounts"`
+	Priority     int8               `validation:"max=5" json:"priority"`
+	GiftNote     *string            `validation:"max_length=200" json:"gift_note"`
+	GiftWrap     bool               `validation:"required_with=GiftNote" json:"gift_wrap"`
+}
+
+// ValidateStruct checks the rules across fields, and is called by Validate after the validations of the tags
+func (o *Order) ValidateStruct() (errs []validate.ValidationError) {
+	if o.Shipping == nil && o.Billing != nil && o.Billing.Country != "US" {
+		errs = append(errs, validate.ValidationError{
+			Key:     "Shipping",
+			Message: "is required outside the US",
+		})
+	}
+	return errs
+}
+
+// main example (validating an order with the generated Validate method)
+func main() {
+	// Register the built-in validations, used by the rules without generated code
+	validate.InitValidations()
+
+	order := &Order{
+		Audit:        Audit{CreatedBy: "john", CreatedAt: time.Now()},
+		ID:           1,
+		Email:        "john@protonmail.com",
+		ConfirmEmail: "john@protonmail.com",
+		Billing:      &Address{Street: "1 Main St", City: "Springfield", Country: "CA"},
+		Items:        []Item{{SKU: "ABC-1", Quantity: 0, Price: 9.99}},
+		Discounts:    map[string]float32{"SUMMER": 0.2},
+	}
+
+	// Validate the order without reflection, the same as validate.IsValid(order)
+	ok, errs := order.Validate()
+	if !ok {
+		log.Printf("Order validation failed! %+v", errs)
+	} else {
+		log.Println("Order validation succeed!")
+	}
+}
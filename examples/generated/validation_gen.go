@@ -0,0 +1,256 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// validationValue0 is a value used by the validations
+var validationValue0 = regexp.MustCompile("^[0-9]{5}$")
+
+// validationValue1 is a value used by the validations
+var validationValue1 = regexp.MustCompile("^[A-Z]{3}-[0-9]+$")
+
+// orderValidationRules are the rules of Order run by their validations
+var orderValidationRules = validate.NewGeneratedRules("main.Order",
+	validate.GeneratedRule{Field: "Email", Index: 2, Rule: "format=email", Kind: reflect.String},
+	validate.GeneratedRule{Field: "ConfirmEmail", Index: 3, Rule: "compare=Email", Kind: reflect.String},
+	validate.GeneratedRule{Field: "GiftWrap", Index: 11, Rule: "required_with=GiftNote", Kind: reflect.Bool},
+)
+
+// Validate determines if the Address is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (a *Address) Validate() (bool, []validate.ValidationError) {
+	if a == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := a.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Address, prefixing their keys with the path to it
+func (a *Address) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// Street
+	if len(a.Street) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Street", Message: "is required", Code: "required", Value: a.Street})
+	}
+	if len(a.Street) < 3 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Street", Message: "must be at least 3 characters", Code: "min_length", Params: []string{"3"}, Value: a.Street})
+	}
+
+	// City
+	if len(a.City) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "City", Message: "is required", Code: "required", Value: a.City})
+	}
+
+	// Country
+	if string(a.Country) != "US" && string(a.Country) != "CA" && string(a.Country) != "MX" {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Country", Message: "must be one of US, CA, MX", Code: "one_of", Params: []string{"US", "CA", "MX"}, Value: a.Country, Err: validate.ErrEnumValueNotAllowed})
+	}
+
+	// Zip
+	omitted1 := a.Zip == nil
+	if !omitted1 {
+		if a.Zip != nil {
+			if !validationValue0.MatchString(string(*a.Zip)) {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Zip", Message: "does not match regexp format", Code: "format", Params: []string{"regexp:^[0-9]{5}$"}, Value: *a.Zip})
+			}
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Audit is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (a *Audit) Validate() (bool, []validate.ValidationError) {
+	if a == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := a.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Audit, prefixing their keys with the path to it
+func (a *Audit) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// CreatedBy
+	if len(a.CreatedBy) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "CreatedBy", Message: "is required", Code: "required", Value: a.CreatedBy})
+	}
+
+	// CreatedAt
+	if a.CreatedAt.IsZero() {
+		errors = append(errors, validate.ValidationError{Key: prefix + "CreatedAt", Message: "is required", Code: "required", Value: a.CreatedAt})
+	}
+	return errors
+}
+
+// Validate determines if the Item is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (i *Item) Validate() (bool, []validate.ValidationError) {
+	if i == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := i.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Item, prefixing their keys with the path to it
+func (i *Item) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// SKU
+	if len(i.SKU) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "SKU", Message: "is required", Code: "required", Value: i.SKU})
+	}
+	if !validationValue1.MatchString(string(i.SKU)) {
+		errors = append(errors, validate.ValidationError{Key: prefix + "SKU", Message: "does not match regexp format", Code: "format", Params: []string{"regexp:^[A-Z]{3}-[0-9]+$"}, Value: i.SKU})
+	}
+
+	// Quantity
+	if int64(i.Quantity) < 1 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Quantity", Message: "must be greater than or equal to 1", Code: "min", Params: []string{"1"}, Value: i.Quantity})
+	}
+	if int64(i.Quantity) > 100 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Quantity", Message: "must be less than or equal to 100", Code: "max", Params: []string{"100"}, Value: i.Quantity})
+	}
+
+	// Price
+	if float64(i.Price) < 0.01 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Price", Message: "must be greater than or equal to 1E-02", Code: "min", Params: []string{"0.01"}, Value: i.Price})
+	}
+	return errors
+}
+
+// Validate determines if the Order is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (o *Order) Validate() (bool, []validate.ValidationError) {
+	if o == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := o.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Order, prefixing their keys with the path to it
+func (o *Order) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := orderValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(o).Elem()
+
+	visit, ok := visited.Visit(o)
+	if !ok {
+		return errors
+	}
+
+	// Audit
+	errors = o.Audit.validateGenerated(errors, prefix, &visit)
+
+	// ID
+	if uint64(o.ID) < 1 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "ID", Message: "must be greater than or equal to 1", Code: "min", Params: []string{"1"}, Value: o.ID})
+	}
+
+	// Email
+	if len(o.Email) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Email", Message: "is required", Code: "required", Value: o.Email})
+	}
+	if err, field := orderValidationRules.Validate(0, o.Email, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Email"
+		}
+		errors = append(errors, *err)
+	}
+
+	// ConfirmEmail
+	if err, field := orderValidationRules.Validate(1, o.ConfirmEmail, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "ConfirmEmail"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Billing
+	if o.Billing == nil {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Billing", Message: "is required", Code: "required", Value: o.Billing})
+	}
+	if o.Billing != nil {
+		errors = o.Billing.validateGenerated(errors, prefix+"Billing.", &visit)
+	}
+
+	// Shipping
+	if o.Shipping != nil {
+		errors = o.Shipping.validateGenerated(errors, prefix+"Shipping.", &visit)
+	}
+
+	// Items
+	if len(o.Items) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Items", Message: "is required", Code: "required", Value: o.Items})
+	}
+	for i1 := range o.Items {
+		errors = o.Items[i1].validateGenerated(errors, prefix+"Items["+strconv.Itoa(i1)+"].", &visit)
+	}
+
+	// Tags
+	omitted2 := len(o.Tags) == 0
+	if !omitted2 {
+		for i3 := range o.Tags {
+			if len(o.Tags[i3]) < 2 {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Tags[" + strconv.Itoa(i3) + "]", Message: "must be at least 2 characters", Code: "min_length", Params: []string{"2"}, Value: o.Tags[i3]})
+			}
+			if len(o.Tags[i3]) > 20 {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Tags[" + strconv.Itoa(i3) + "]", Message: "must be no more than 20 characters", Code: "max_length", Params: []string{"20"}, Value: o.Tags[i3]})
+			}
+		}
+	}
+
+	// Discounts
+	keys5, names6 := validate.GeneratedMapKeys(o.Discounts)
+	for i7, key8 := range keys5 {
+		if len(key8) < 3 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Discounts[" + names6[i7] + "]", Message: "must be at least 3 characters", Code: "min_length", Params: []string{"3"}, Value: key8})
+		}
+		element9 := o.Discounts[key8]
+		if float64(element9) < 0 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Discounts[" + names6[i7] + "]", Message: "must be greater than or equal to 0E+00", Code: "min", Params: []string{"0"}, Value: element9})
+		}
+		if float64(element9) > 1 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Discounts[" + names6[i7] + "]", Message: "must be less than or equal to 1E+00", Code: "max", Params: []string{"1"}, Value: element9})
+		}
+	}
+
+	// Priority
+	if int64(o.Priority) > 5 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Priority", Message: "must be less than or equal to 5", Code: "max", Params: []string{"5"}, Value: o.Priority})
+	}
+
+	// GiftNote
+	if o.GiftNote != nil {
+		if len(*o.GiftNote) > 200 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "GiftNote", Message: "must be no more than 200 characters", Code: "max_length", Params: []string{"200"}, Value: *o.GiftNote})
+		}
+	}
+
+	// GiftWrap
+	if err, field := orderValidationRules.Validate(2, o.GiftWrap, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "GiftWrap"
+		}
+		errors = append(errors, *err)
+	}
+
+	errors = validate.GeneratedStructErrors(errors, o.ValidateStruct(), prefix)
+	return errors
+}
@@ -0,0 +1,31 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// TestGeneratedValidations cross-checks the generated validations with validate.IsValid, for the
+// zero value and random values of each type
+func TestGeneratedValidations(t *testing.T) {
+	validate.InitValidations()
+
+	random := rand.New(rand.NewSource(1))
+	for _, object := range []validate.GeneratedValidator{
+		&Address{},
+		&Audit{},
+		&Item{},
+		&Order{},
+	} {
+		if err := validate.CrossCheck(object); err != nil {
+			t.Error(err)
+		}
+		if err := validate.CrossCheckRandom(object, random, 1000); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -23,13 +23,11 @@ type Customer struct {
 	SocialSecurityNumber string  `json:"-"`
 }
 
-// Valid is a custom method for the model that will run all built-in validations and also run any custom validations
-func (c *Customer) Valid() (bool, []validate.ValidationError) {
-	// Run all validations off the struct configuration
-	_, errs := validate.IsValid(*c)
-
+// ValidateStruct runs any custom validations for the model, and is called by validate.IsValid
+// after all the built-in validations from the struct configuration
+func (c *Customer) ValidateStruct() (errs []validate.ValidationError) {
 	//
-	// Customize: errs (you can add/remove your own errors)
+	// Customize: errs (you can add your own errors)
 	//
 
 	// Showing use of a public validation method (extra validations outside the struct built-in validations)
@@ -41,14 +39,16 @@ func (c *Customer) Valid() (bool, []validate.ValidationError) {
 		})
 	}
 
-	// Return error if found
-	return len(errs) == 0, errs
+	return errs
 }
 
 // Add your custom validations here using AddValidation() function calls if needed
 
 // main example (just an example of validating a model's data before persisting into a database)
 func main() {
+	// Register the built-in validations
+	validate.InitValidations()
+
 	// Start with some model and data
 	customer := &Customer{
 		Age:                  21,
@@ -62,9 +62,9 @@ func main() {
 		SocialSecurityNumber: "212126768",
 	}
 
-	// Validate the model - Run before saving into a database
-	_, errs := customer.Valid()
-	if errs != nil {
+	// Validate the model (built-in and custom validations) - Run before saving into a database
+	ok, errs := validate.IsValid(customer)
+	if !ok {
 		log.Printf("Customer validation failed! %+v", errs)
 	} else {
 		log.Println("Customer validation succeed!")
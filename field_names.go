@@ -0,0 +1,112 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNameFunc names a struct field in the keys and messages of validation errors, e.g. by its
+// json tag. The Go name of the field is used when it returns an empty name.
+type FieldNameFunc func(field reflect.StructField) string
+
+// JSONFieldNames names the fields by their json tag (see TagFieldNames)
+func JSONFieldNames(field reflect.StructField) string {
+	return tagFieldName(field, "json")
+}
+
+// TagFieldNames creates a FieldNameFunc naming the fields by the name in the tag, as encoding/json
+// reads the json tag (e.g. `form:"first_name,omitempty"` names the field first_name). Fields
+// without a name in the tag, or skipped with "-", keep their Go name, while "-," names them "-".
+func TagFieldNames(tag string) FieldNameFunc {
+	return func(field reflect.StructField) string {
+		return tagFieldName(field, tag)
+	}
+}
+
+// tagFieldName gets the name of the field in the tag, empty when there is none
+func tagFieldName(field reflect.StructField, tag string) string {
+	value := field.Tag.Get(tag)
+	if value == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(value, ",")
+	return name
+}
+
+// SetFieldNames sets the naming of the fields in the keys of the errors (including nested paths,
+// such as "address.city" and "items[0].name") and in the messages mentioning other fields (e.g.
+// compare) and their parameters, nil for their Go names. Struct fields embedded without a name are
+// still promoted. Compile errors keep the Go names, as they are about the Go code.
+func (m *Map) SetFieldNames(names FieldNameFunc) {
+	m.fieldNamesLock.Lock()
+	m.fieldNames = names
+	m.fieldNamesLock.Unlock()
+
+	// The names are stored in the plans
+	m.resetPlans()
+}
+
+// SetFieldNames sets the naming of the fields of DefaultMap (see Map.SetFieldNames)
+func SetFieldNames(names FieldNameFunc) {
+	DefaultMap.SetFieldNames(names)
+}
+
+// fieldNaming returns the naming of the fields, nil for their Go names
+func (m *Map) fieldNaming() FieldNameFunc {
+	m.fieldNamesLock.RLock()
+	defer m.fieldNamesLock.RUnlock()
+	return m.fieldNames
+}
+
+// fieldsNamer is implemented by validations mentioning other fields of the struct in their errors,
+// which name them as the map names the fields (see SetFieldNames)
+type fieldsNamer interface {
+	setFieldNames(names FieldNameFunc)
+}
+
+// fieldNamer names the other fields of the struct a validation mentions in its errors, and can be
+// embedded in validations to implement fieldsNamer
+type fieldNamer struct {
+	// names is the naming of the fields, nil for their Go names
+	names FieldNameFunc
+}
+
+// setFieldNames stores the naming of the fields
+func (f *fieldNamer) setFieldNames(names FieldNameFunc) {
+	f.names = names
+}
+
+// fieldPath names each field of the dotted path (e.g. "Range.Start") of the struct obj, keeping
+// the segments that are not struct fields (e.g. the keys of a Schema map)
+func (f *fieldNamer) fieldPath(obj reflect.Value, path string) string {
+	if f.names == nil || !obj.IsValid() {
+		return path
+	}
+
+	segments := strings.Split(path, ".")
+	objectType := obj.Type()
+	for i, segment := range segments {
+		objectType = indirectType(objectType)
+		if objectType.Kind() != reflect.Struct {
+			break
+		}
+		field, ok := objectType.FieldByName(segment)
+		if !ok {
+			break
+		}
+		if name := f.names(field); len(name) > 0 {
+			segments[i] = name
+		}
+		objectType = field.Type
+	}
+	return strings.Join(segments, ".")
+}
+
+// fieldPaths names the dotted paths of the struct obj (see fieldPath)
+func (f *fieldNamer) fieldPaths(obj reflect.Value, paths []string) []string {
+	named := make([]string, len(paths))
+	for i, path := range paths {
+		named[i] = f.fieldPath(obj, path)
+	}
+	return named
+}
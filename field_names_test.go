@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedAddress is nested in namedSignup
+type namedAddress struct {
+	City  string `json:"city" validation:"required"`
+	Since int    `json:"since"`
+	Until int    `json:"until" validation:"gte_field=Since"`
+}
+
+// NamedAudit is embedded in namedSignup without a json name, so its fields are promoted
+type NamedAudit struct {
+	Source string `json:"source" validation:"max_length=3"`
+}
+
+// NamedOwner is embedded in namedSignup with a json name
+type NamedOwner struct {
+	Name string `json:"name" validation:"required"`
+}
+
+// namedSignup has fields named by their json and form tags
+type namedSignup struct {
+	NamedAudit
+	NamedOwner `json:"owner"`
+
+	Email     string         `json:"email" form:"email_address" validation:"required"`
+	Password  string         `json:"password" validation:"min_length=8"`
+	Confirm   string         `json:"password_confirmation" validation:"compare=Password"`
+	Nick      string         `json:"-" validation:"max_length=3"`
+	Dash      string         `json:"-," validation:"max_length=3"`
+	Plain     string         `json:",omitempty" validation:"max_length=3"`
+	Country   string         `json:"country"`
+	State     string         `json:"state" validation:"required_if=Country,US"`
+	Address   *namedAddress  `json:"address"`
+	Addresses []namedAddress `json:"addresses" validation:"dive"`
+	Tags      []string       `json:"tags" validation:"dive min_length=2"`
+	Range     namedAddress   `json:"range"`
+	Latest    int            `json:"latest" validation:"lte_field=Range.Until"`
+}
+
+// invalidSignup is a namedSignup with an error in each field
+func invalidSignup() namedSignup {
+	return namedSignup{
+		NamedAudit: NamedAudit{Source: "mobile"},
+		Password:   "secret",
+		Confirm:    "other",
+		Nick:       "long",
+		Dash:       "long",
+		Plain:      "long",
+		Country:    "US",
+		Address:    &namedAddress{Since: 2020, Until: 2010},
+		Addresses:  []namedAddress{{City: "Austin"}, {}},
+		Tags:       []string{"a"},
+		Range:      namedAddress{City: "Austin", Since: 10, Until: 5},
+		Latest:     9,
+	}
+}
+
+// TestFieldNames tests the tag names of the fields
+func TestFieldNames(t *testing.T) {
+	signupType := reflect.TypeOf(namedSignup{})
+	tests := []struct {
+		field string
+		json  string
+		form  string
+	}{
+		{"Email", "email", "email_address"},
+		{"Confirm", "password_confirmation", ""},
+		{"Nick", "", ""},
+		{"Dash", "-", ""},
+		{"Plain", "", ""},
+	}
+	for _, test := range tests {
+		field, ok := signupType.FieldByName(test.field)
+		require.True(t, ok)
+		assert.Equal(t, test.json, JSONFieldNames(field), test.field)
+		assert.Equal(t, test.form, TagFieldNames("form")(field), test.field)
+	}
+}
+
+// TestMapSetFieldNames tests naming the fields in the keys and messages of the errors
+func TestMapSetFieldNames(t *testing.T) {
+	m := NewMap()
+
+	// Plans built before the names are set are replaced
+	_, errs := m.IsValid(invalidSignup())
+	require.Len(t, errs, 15)
+	assert.Equal(t, "Source", errs[0].Key)
+
+	m.SetFieldNames(JSONFieldNames)
+	_, errs = m.IsValid(invalidSignup())
+	assert.Equal(t, []ValidationError{
+		{Key: "source", Message: "must be no more than 3 characters"},
+		{Key: "owner.name", Message: "is required"},
+		{Key: "email", Message: "is required"},
+		{Key: "password", Message: "must be at least 8 characters"},
+		{Key: "password_confirmation", Message: "is not the same as the compare field password"},
+		{Key: "Nick", Message: "must be no more than 3 characters"},
+		{Key: "-", Message: "must be no more than 3 characters"},
+		{Key: "Plain", Message: "must be no more than 3 characters"},
+		{Key: "state", Message: "is required when country is US"},
+		{Key: "address.city", Message: "is required"},
+		{Key: "address.until", Message: "must be greater than or equal to since"},
+		{Key: "addresses[1].city", Message: "is required"},
+		{Key: "tags[0]", Message: "must be at least 2 characters"},
+		{Key: "range.until", Message: "must be greater than or equal to since"},
+		{Key: "latest", Message: "must be less than or equal to range.until"},
+	}, withoutDetails(errs))
+
+	// The parameters of the errors name the other fields too, for translated messages
+	assert.Equal(t, []string{"password"}, errs[4].Params)
+	assert.Equal(t, []string{"country", "US"}, errs[8].Params)
+	assert.Equal(t, []string{"range.until"}, errs[14].Params)
+	translated := ValidationErrors(errs).Translate(NewTranslator(), "es")
+	assert.Equal(t, "password_confirmation no coincide con el campo password", translated[4].Error())
+
+	// A custom naming, where the fields it has no name for keep their Go names
+	m.SetFieldNames(func(field reflect.StructField) string {
+		if field.Name == "Confirm" {
+			return ""
+		}
+		return strings.ToUpper(field.Name)
+	})
+	_, errs = m.IsValid(invalidSignup())
+	assert.Equal(t, ValidationError{Key: "Confirm", Message: "is not the same as the compare field PASSWORD"},
+		withoutDetails(errs)[4])
+	assert.Equal(t, "NAMEDOWNER.NAME", errs[1].Key)
+
+	// Clones keep the names, and the Go names are restored with nil
+	clone := m.Clone()
+	m.SetFieldNames(nil)
+	_, errs = m.IsValid(invalidSignup())
+	assert.Equal(t, "Name", errs[1].Key)
+	assert.Equal(t, "is not the same as the compare field Password", errs[4].Message)
+	assert.Equal(t, []string{"Password"}, errs[4].Params)
+	_, errs = clone.IsValid(invalidSignup())
+	assert.Equal(t, "NAMEDOWNER.NAME", errs[1].Key)
+}
+
+// TestSetFieldNames tests naming the fields of DefaultMap
+func TestSetFieldNames(t *testing.T) {
+	InitValidations()
+	SetFieldNames(TagFieldNames("form"))
+	defer SetFieldNames(nil)
+
+	_, errs := IsValid(invalidSignup())
+	require.NotEmpty(t, errs)
+	assert.Equal(t, "email_address", errs[2].Key)
+	assert.Equal(t, "Password", errs[3].Key)
+}
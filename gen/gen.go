@@ -0,0 +1,1319 @@
+/*
+Package gen generates Validate methods for structs from their validation tags (see cmd/validate-gen),
+running the same validations as validate.IsValid with the same errors, without reflection.
+*/
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// validatePath is the import path of the validate package
+const validatePath = "github.com/mrz1836/go-validate"
+
+// Static error definitions to satisfy err113 linter
+var (
+	ErrNotGenerated    = errors.New("rule has no generated code")
+	ErrUnknownType     = errors.New("unknown struct type")
+	ErrValidateMethod  = errors.New("type already has a Validate method")
+	ErrUnexportedField = errors.New("validation tags on unexported fields are not supported")
+	ErrNoPackage       = errors.New("no Go files to generate the validations of")
+)
+
+// RuleGenerator generates the statements of a rule for a value, appending an error with
+// value.Fail when the value is invalid. ErrNotGenerated is returned for rules (or values) it has
+// no code for, which are then run through their validation in validate.DefaultMap.
+type RuleGenerator func(rule validate.Rule, value *Value) (string, error)
+
+// ruleGenerator is a registered rule generator
+type ruleGenerator struct {
+	fn RuleGenerator
+
+	// presence is set for presence rules (see validate.PresenceValidation)
+	presence bool
+}
+
+// Generator generates the validations of struct types
+type Generator struct {
+	rules map[string]ruleGenerator
+
+	// fieldNameTag is the tag naming the fields in the keys of the errors, none for their Go names
+	fieldNameTag string
+}
+
+// NewGenerator creates a generator with the code of the built-in rules
+func NewGenerator() *Generator {
+	g := &Generator{rules: map[string]ruleGenerator{}}
+	g.AddPresenceRule("required", requiredRule)
+	g.AddRule("min_length", lengthRule(true))
+	g.AddRule("max_length", lengthRule(false))
+	g.AddRule("format", formatRule)
+	g.AddRule("one_of", oneOfRule)
+	g.AddRule("min", boundRule(true))
+	g.AddRule("max", boundRule(false))
+	return g
+}
+
+// AddRule registers the code of the rules of a validation, such as a custom validation
+// registered with validate.AddValidation. The value of the rule is the value pointers lead to,
+// and the rule is skipped for nil pointers.
+func (g *Generator) AddRule(name string, fn RuleGenerator) {
+	g.rules[name] = ruleGenerator{fn: fn}
+}
+
+// AddPresenceRule registers the code of the rules of a presence validation (see
+// validate.PresenceValidation), which receive the value as declared, including nil pointers
+func (g *Generator) AddPresenceRule(name string, fn RuleGenerator) {
+	g.rules[name] = ruleGenerator{fn: fn, presence: true}
+}
+
+// SetFieldNameTag names the fields in the keys of the errors by the name in the tag (e.g. "json"),
+// as validate.TagFieldNames does, empty for their Go names. The generated code must run with the same
+// names in validate.DefaultMap (see validate.SetFieldNames), which the generated test sets.
+func (g *Generator) SetFieldNameTag(tag string) {
+	g.fieldNameTag = tag
+}
+
+// Value is the value a rule is generated for
+type Value struct {
+	// Expr is the Go expression of the value
+	Expr string
+
+	// Type is the type of the value
+	Type types.Type
+
+	// Kind is the kind of the value as validations are built for it (the kind pointers lead to)
+	Kind reflect.Kind
+
+	// key is the Go expression of the key of the errors
+	key string
+
+	// rule is the rule generated for the value, describing its errors
+	rule validate.Rule
+
+	// label is the label of the field in messages
+	label string
+
+	// file is the generated file
+	file *file
+}
+
+// Fail returns the statement appending the error with the message for the value
+func (v *Value) Fail(message string) string {
+	return v.FailWith(message, "")
+}
+
+// FailWith returns the statement appending the error with the message for the value, wrapping the
+// sentinel error of the expression (e.g. "validate.ErrEnumValueNotAllowed", none when empty)
+func (v *Value) FailWith(message, err string) string {
+	fields := []string{"Key: " + v.key}
+	if len(v.label) > 0 {
+		fields = append(fields, "Label: "+strconv.Quote(v.label))
+	}
+	fields = append(fields, "Message: "+strconv.Quote(message), "Code: "+strconv.Quote(v.rule.Name))
+	if len(v.rule.Params) > 0 {
+		params := make([]string, len(v.rule.Params))
+		for i, param := range v.rule.Params {
+			params[i] = strconv.Quote(param)
+		}
+		fields = append(fields, "Params: []string{"+strings.Join(params, ", ")+"}")
+	}
+	if _, redacted := v.rule.Options["redact"]; !redacted {
+		fields = append(fields, "Value: "+argument(v.Expr))
+	}
+	if len(err) > 0 {
+		fields = append(fields, "Err: "+err)
+	}
+	return "errors = append(errors, validate.ValidationError{" + strings.Join(fields, ", ") + "})\n"
+}
+
+// Empty returns the expression determining if the value is empty, as required sees it
+func (v *Value) Empty() string {
+	return v.file.empty(v.Expr, v.Type)
+}
+
+// Import imports the package of the path into the generated file, returning its name
+func (v *Value) Import(importPath string) string {
+	return v.file.importName(importPath, path.Base(importPath))
+}
+
+// Declare declares a package variable with the value of the expression (e.g. a compiled regular
+// expression), returning its name. The same expression is declared once.
+func (v *Value) Declare(expr string) string {
+	return v.file.declare(expr)
+}
+
+// Generate generates the Validate methods of the struct types of the package in the directory, and
+// of the struct types of the package they validate, or of all the struct types with validations
+// when no names are given. The output file and its test are ignored when loading the package, and
+// the source of the test cross-checking the generated code with validate.IsValid is also returned.
+func (g *Generator) Generate(dir, output string, typeNames ...string) ([]byte, []byte, error) {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := newFile(g, pkg)
+	if len(typeNames) == 0 {
+		for _, name := range pkg.Scope().Names() {
+			if named := f.structType(pkg.Scope().Lookup(name)); named != nil && f.hasValidations(named, nil) {
+				typeNames = append(typeNames, name)
+			}
+		}
+	}
+	for _, name := range typeNames {
+		named := f.structType(pkg.Scope().Lookup(name))
+		if named == nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownType, name)
+		}
+		if hasMethod(named, "Validate") {
+			return nil, nil, fmt.Errorf("%w: %s", ErrValidateMethod, name)
+		}
+		f.generate(named)
+	}
+
+	for len(f.queue) > 0 {
+		named := f.queue[0]
+		f.queue = f.queue[1:]
+		if err = f.generateStruct(named); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	source, err := f.source()
+	if err != nil {
+		return nil, nil, err
+	}
+	test, err := f.testSource()
+	if err != nil {
+		return nil, nil, err
+	}
+	return source, test, nil
+}
+
+// Main runs the generator with the command line arguments of validate-gen:
+//
+//	validate-gen [-type Customer,Order] [-output validation_gen.go] [-names json] [-test] [directory]
+//
+// The methods are written to the output file of the directory (the current directory by default),
+// and the test cross-checking them with validate.IsValid to its _test.go file with -test.
+func (g *Generator) Main(args []string) error {
+	flags := flag.NewFlagSet("validate-gen", flag.ContinueOnError)
+	typeNames := flags.String("type", "", "comma separated names of the struct types (all struct types with validations by default)")
+	output := flags.String("output", "validation_gen.go", "name of the generated file")
+	fieldNames := flags.String("names", "", "tag naming the fields in the error keys (e.g. json, Go names by default)")
+	test := flags.Bool("test", false, "generate a test cross-checking the generated code with validate.IsValid")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(*fieldNames) > 0 {
+		g.SetFieldNameTag(*fieldNames)
+	}
+
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+	var names []string
+	if len(*typeNames) > 0 {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	source, testSource, err := g.Generate(dir, *output, names...)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, *output), source, 0o644); err != nil { //nolint:gosec // source files are readable
+		return err
+	}
+	if *test {
+		testOutput := strings.TrimSuffix(*output, ".go") + "_test.go"
+		return os.WriteFile(filepath.Join(dir, testOutput), testSource, 0o644) //nolint:gosec // source files are readable
+	}
+	return nil
+}
+
+// loadPackage parses and type checks the package in the directory (for the build tags of
+// the environment), ignoring the output file and its test
+func loadPackage(dir, output string) (*types.Package, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	buildPackage, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPackage.GoFiles {
+		if name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoPackage, dir)
+	}
+
+	// Code using the generated methods does not type check without them, which is ignored
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := config.Check(buildPackage.ImportPath, fset, files, nil)
+	return pkg, nil
+}
+
+// file is a generated file
+type file struct {
+	g   *Generator
+	pkg *types.Package
+
+	// imports are the names of the imported packages by path, and packageNames their declared names
+	imports      map[string]string
+	packageNames map[string]string
+
+	// declarations are the names of the declared variables by expression, in order of declaration
+	declarations map[string]string
+	declared     []string
+
+	// generated are the struct types generated (or to generate), queue those left to generate
+	generated map[*types.Named]bool
+	queue     []*types.Named
+
+	// methods are the generated methods of the types by type name, and rules their rules
+	methods map[string]string
+	rules   map[string]string
+}
+
+// newFile creates a file of the package
+func newFile(g *Generator, pkg *types.Package) *file {
+	f := &file{
+		g:            g,
+		pkg:          pkg,
+		imports:      map[string]string{},
+		packageNames: map[string]string{},
+		declarations: map[string]string{},
+		generated:    map[*types.Named]bool{},
+		methods:      map[string]string{},
+		rules:        map[string]string{},
+	}
+	f.importName(validatePath, "validate")
+	return f
+}
+
+// importName imports the package of the path, renaming it when the name is taken
+func (f *file) importName(importPath, name string) string {
+	if imported, ok := f.imports[importPath]; ok {
+		return imported
+	}
+
+	taken := map[string]bool{}
+	for _, imported := range f.imports {
+		taken[imported] = true
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	f.imports[importPath], f.packageNames[importPath] = unique, name
+	return unique
+}
+
+// declare declares a package variable with the value of the expression
+func (f *file) declare(expr string) string {
+	if name, ok := f.declarations[expr]; ok {
+		return name
+	}
+	name := "validationValue" + strconv.Itoa(len(f.declared))
+	f.declarations[expr] = name
+	f.declared = append(f.declared, expr)
+	return name
+}
+
+// structType gets the non-generic named struct type of the object, nil for any other object
+func (f *file) structType(object types.Object) *types.Named {
+	typeName, ok := object.(*types.TypeName)
+	if !ok || typeName.IsAlias() {
+		return nil
+	}
+	named, ok := typeName.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return nil
+	}
+	if _, ok = named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	return named
+}
+
+// generate adds the type to the types to generate
+func (f *file) generate(named *types.Named) {
+	if !f.generated[named] {
+		f.generated[named] = true
+		f.queue = append(f.queue, named)
+	}
+}
+
+// generates determines if the struct type pointers lead to is generated in the file, adding
+// the named structs of the package to generate
+func (f *file) generates(named *types.Named) bool {
+	if f.generated[named] {
+		return true
+	}
+	if named.Obj().Pkg() != f.pkg || f.structType(named.Obj()) == nil || hasMethod(named, "Validate") {
+		return false
+	}
+	f.generate(named)
+	return true
+}
+
+// hasValidations determines if a struct type has validations (its fields' tags, nested structs
+// with validations or a ValidateStruct method), seen guards against recursive types
+func (f *file) hasValidations(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	if seen == nil {
+		seen = map[types.Type]bool{}
+	}
+	seen[t] = true
+
+	structType, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	if isStructValidator(t) {
+		return true
+	}
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		if len(reflect.StructTag(structType.Tag(i)).Get("validation")) > 0 {
+			return true
+		}
+		if base, _ := indirect(field.Type()); field.Exported() && isStruct(base) && f.hasValidations(base, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateStruct generates the methods and the rules of a struct type
+func (f *file) generateStruct(named *types.Named) error {
+	s := &structGen{file: f, named: named, receiver: receiverName(named), visit: "visit"}
+	if err := s.generate(); err != nil {
+		return err
+	}
+
+	name := named.Obj().Name()
+	var method bytes.Buffer
+	fmt.Fprintf(&method, `// Validate determines if the %[1]s is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (%[2]s *%[1]s) Validate() (bool, []validate.ValidationError) {
+	if %[2]s == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := %[2]s.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the %[1]s, prefixing their keys with the path to it
+func (%[2]s *%[1]s) validateGenerated(errors []validate.ValidationError, prefix string,
+	%[3]s *validate.GeneratedVisit,
+) []validate.ValidationError {
+`, name, s.receiver, map[bool]string{false: "_", true: "visited"}[s.visited])
+
+	if len(s.fallbacks) > 0 {
+		rulesName := s.rulesName()
+		f.importName("reflect", "reflect")
+		fmt.Fprintf(&method, `errors, ok := %[1]s.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(%[2]s).Elem()
+
+`, rulesName, s.receiver)
+
+		var rules bytes.Buffer
+		fmt.Fprintf(&rules, "// %s are the rules of %s run by their validations\n", rulesName, name)
+		fmt.Fprintf(&rules, "var %s = validate.NewGeneratedRules(%q,\n", rulesName, f.pkg.Name()+"."+name)
+		for _, fallback := range s.fallbacks {
+			label := ""
+			if len(fallback.Label) > 0 {
+				label = fmt.Sprintf(", Label: %q", fallback.Label)
+			}
+			fmt.Fprintf(&rules, "validate.GeneratedRule{Field: %q, Index: %d, Rule: %q, Kind: reflect.%s%s},\n",
+				fallback.Field, fallback.Index, fallback.Rule, kindName(fallback.Kind), label)
+		}
+		rules.WriteString(")\n")
+		f.rules[name] = rules.String()
+	}
+
+	// Structs holding values that lead to other structs are visited, to stop at cycles of pointers
+	if s.visited {
+		fmt.Fprintf(&method, `visit, ok := visited.Visit(%s)
+	if !ok {
+		return errors
+	}
+
+`, s.receiver)
+	}
+
+	method.Write(s.body.Bytes())
+	method.WriteString("return errors\n}\n")
+	f.methods[name] = method.String()
+	return nil
+}
+
+// source formats the generated file
+func (f *file) source() ([]byte, error) {
+	var source bytes.Buffer
+	source.WriteString("// Code generated by validate-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", f.pkg.Name())
+
+	// The standard library is imported first
+	var standard, others []string
+	for importPath := range f.imports {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			others = append(others, importPath)
+		} else {
+			standard = append(standard, importPath)
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(others)
+	source.WriteString("import (\n")
+	for i, importPath := range append(standard, others...) {
+		if i == len(standard) && i > 0 {
+			source.WriteString("\n")
+		}
+		if name := f.imports[importPath]; name != f.packageNames[importPath] {
+			fmt.Fprintf(&source, "%s %q\n", name, importPath)
+		} else {
+			fmt.Fprintf(&source, "%q\n", importPath)
+		}
+	}
+	source.WriteString(")\n\n")
+
+	for i, expr := range f.declared {
+		fmt.Fprintf(&source, "// validationValue%d is a value used by the validations\nvar validationValue%d = %s\n\n",
+			i, i, expr)
+	}
+	for _, name := range sortedNames(f.rules) {
+		source.WriteString(f.rules[name] + "\n")
+	}
+	for _, name := range sortedNames(f.methods) {
+		source.WriteString(f.methods[name] + "\n")
+	}
+
+	return format.Source(source.Bytes())
+}
+
+// testSource formats the test cross-checking the generated code with validate.IsValid
+func (f *file) testSource() ([]byte, error) {
+	var source bytes.Buffer
+	source.WriteString("// Code generated by validate-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", f.pkg.Name())
+	fmt.Fprintf(&source, `import (
+	"math/rand"
+	"testing"
+
+	%q
+)
+
+// TestGeneratedValidations cross-checks the generated validations with validate.IsValid, for the
+// zero value and random values of each type
+func TestGeneratedValidations(t *testing.T) {
+	validate.InitValidations()
+`, validatePath)
+	if len(f.g.fieldNameTag) > 0 {
+		fmt.Fprintf(&source, "validate.SetFieldNames(validate.TagFieldNames(%q))\n", f.g.fieldNameTag)
+	}
+	source.WriteString(`
+	random := rand.New(rand.NewSource(1))
+	for _, object := range []validate.GeneratedValidator{
+`)
+	for _, name := range sortedNames(f.methods) {
+		fmt.Fprintf(&source, "&%s{},\n", name)
+	}
+	source.WriteString(`} {
+		if err := validate.CrossCheck(object); err != nil {
+			t.Error(err)
+		}
+		if err := validate.CrossCheckRandom(object, random, 1000); err != nil {
+			t.Error(err)
+		}
+	}
+}
+`)
+	return format.Source(source.Bytes())
+}
+
+// structGen generates the validations of a struct type
+type structGen struct {
+	file     *file
+	named    *types.Named
+	receiver string
+
+	// fallbacks are the rules run by their validations, in the order IsValid builds them
+	fallbacks []validate.GeneratedRule
+
+	// body is the generated code, and variables the number of variables declared in it
+	body      bytes.Buffer
+	variables int
+
+	// visit is the variable of the visit of the struct or map holding the values being generated,
+	// and visited is set when their code uses it (see validate.GeneratedVisit)
+	visit   string
+	visited bool
+}
+
+// rulesName is the name of the variable holding the rules run by their validations
+func (s *structGen) rulesName() string {
+	name := s.named.Obj().Name()
+	return strings.ToLower(name[:1]) + name[1:] + "ValidationRules"
+}
+
+// compileError creates the error of a tag (or rule) of a field the validations cannot be generated for
+func (s *structGen) compileError(field *types.Var, tag string, err error) error {
+	return &validate.CompileError{
+		Struct: s.file.pkg.Name() + "." + s.named.Obj().Name(),
+		Field:  field.Name(),
+		Tag:    tag,
+		Err:    err,
+	}
+}
+
+// variable returns a new variable name with the prefix
+func (s *structGen) variable(prefix string) string {
+	s.variables++
+	return prefix + strconv.Itoa(s.variables)
+}
+
+// generate generates the validations of the fields, in the order IsValid runs them, and ValidateStruct
+func (s *structGen) generate() error {
+	structType := s.named.Underlying().(*types.Struct)
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		tag := reflect.StructTag(structType.Tag(i)).Get("validation")
+		nested := field.Exported() && isStruct(field.Type())
+
+		if len(tag) == 0 && !nested {
+			continue
+		}
+		if len(tag) > 0 && !field.Exported() {
+			return s.compileError(field, tag, ErrUnexportedField)
+		}
+
+		label := reflect.StructTag(structType.Tag(i)).Get("label")
+		rules, err := s.parseRules(field, tag, label)
+		if err != nil {
+			return s.compileError(field, tag, err)
+		}
+
+		// Embedded structs are promoted, unless the tag gives them a name
+		name := s.file.g.fieldName(field, structType.Tag(i))
+		rules.nested, rules.promoted = nested, nested && field.Embedded() && len(name) == 0
+		if len(name) == 0 {
+			name = field.Name()
+		}
+		if !s.file.active(rules, field.Type()) {
+			continue
+		}
+
+		if s.body.Len() > 0 {
+			s.body.WriteString("\n")
+		}
+		fmt.Fprintf(&s.body, "// %s\n", field.Name())
+		if err = s.value(rules, field, i, s.receiver+"."+field.Name(), field.Type(),
+			[]string{"prefix", strconv.Quote(name)}); err != nil {
+			return err
+		}
+	}
+
+	if isStructValidator(s.named) {
+		if s.body.Len() > 0 {
+			s.body.WriteString("\n")
+		}
+		fmt.Fprintf(&s.body, "errors = validate.GeneratedStructErrors(errors, %s.ValidateStruct(), prefix)\n", s.receiver)
+	}
+	return nil
+}
+
+// fieldName gets the name of the field in the keys of the errors as validate.TagFieldNames names it,
+// empty for its Go name
+func (g *Generator) fieldName(field *types.Var, tag string) string {
+	if len(g.fieldNameTag) == 0 {
+		return ""
+	}
+	return validate.TagFieldNames(g.fieldNameTag)(reflect.StructField{Name: field.Name(), Tag: reflect.StructTag(tag)})
+}
+
+// ruleSet is the set of rules applied to a value, and how to descend into it (see the ruleSet
+// of the validate package)
+type ruleSet struct {
+	rules         []validate.Rule
+	omitEmpty     bool
+	omitEmptyFrom int
+	nested        bool
+	promoted      bool
+	elements      *ruleSet
+	keys          *ruleSet
+	label         string
+}
+
+// parseRules parses the tag of a field into rule sets with the label of the field, as the validate
+// package builds them
+func (s *structGen) parseRules(field *types.Var, tag, label string) (*ruleSet, error) {
+	parsed, err := validate.ParseTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldRules := &ruleSet{label: label}
+	rules, ruleType := fieldRules, field.Type()
+	var collection *ruleSet
+	var collectionType types.Type
+	inKeys := false
+
+	for i, rule := range parsed {
+		afterDive := i > 0 && parsed[i-1].Source == "dive"
+
+		modifier := ""
+		if rule.Params == nil {
+			modifier = rule.Name
+		}
+		isModifier := modifier == "dive" || modifier == "keys" || modifier == "endkeys" || modifier == "omitempty"
+		if isModifier && rule.Options != nil {
+			return nil, validate.ErrModifierOptions
+		}
+
+		switch modifier {
+		case "dive":
+			base, _ := indirect(ruleType)
+			elementType := elem(base)
+			if inKeys || elementType == nil {
+				return nil, validate.ErrInvalidDive
+			}
+			collection, collectionType = rules, base
+			if collection.elements == nil {
+				collection.elements = &ruleSet{nested: isStruct(elementType), label: label}
+			}
+			rules, ruleType = collection.elements, elementType
+			continue
+		case "keys":
+			mapType, isMap := collectionType.Underlying().(*types.Map)
+			if !afterDive || !isMap {
+				return nil, validate.ErrInvalidKeys
+			}
+			if collection.keys == nil {
+				collection.keys = &ruleSet{nested: isStruct(mapType.Key()), label: label}
+			}
+			rules, ruleType = collection.keys, mapType.Key()
+			inKeys = true
+			continue
+		case "endkeys":
+			if !inKeys {
+				return nil, validate.ErrMissingKeys
+			}
+			rules, ruleType = collection.elements, elem(collectionType)
+			inKeys = false
+			continue
+		case "omitempty":
+			if !rules.omitEmpty {
+				rules.omitEmpty, rules.omitEmptyFrom = true, len(rules.rules)
+			}
+			continue
+		}
+
+		// Rules limited to groups only run for IsValidGroups
+		for option, params := range rule.Options {
+			switch {
+			case isMessageOption(option) && len(strings.Join(params, ",")) == 0:
+				return nil, validate.ErrMessageRequired
+			case !isMessageOption(option) && option != "groups" && option != "redact":
+				return nil, fmt.Errorf("%w: %s", validate.ErrUnknownOption, option)
+			}
+		}
+		if _, ok := rule.Options["groups"]; ok {
+			continue
+		}
+		rules.rules = append(rules.rules, rule)
+	}
+
+	if inKeys {
+		return nil, validate.ErrMissingEndKeys
+	}
+	return fieldRules, nil
+}
+
+// active determines if a rule set validates the value of the type, or descends into it
+func (f *file) active(rules *ruleSet, valueType types.Type) bool {
+	return len(rules.rules) > 0 || f.descends(rules, valueType)
+}
+
+// descends determines if a rule set descends into the value of the type, with rules (or nested
+// structs) for its elements or keys
+func (f *file) descends(rules *ruleSet, valueType types.Type) bool {
+	base, _ := indirect(valueType)
+	if rules.nested && isStruct(base) {
+		return f.hasValidations(base, nil)
+	}
+	if mapType, ok := base.Underlying().(*types.Map); ok && rules.keys != nil && f.active(rules.keys, mapType.Key()) {
+		return true
+	}
+	return rules.elements != nil && f.active(rules.elements, elem(base))
+}
+
+// value generates the validations of a rule set for the value of the expression (of the field of
+// the struct), with errors keyed by the key expression, as validate runs them
+func (s *structGen) value(rules *ruleSet, field *types.Var, index int, expr string, valueType types.Type,
+	key []string,
+) error {
+	if !s.file.active(rules, valueType) {
+		return nil
+	}
+
+	// The value pointers lead to, and whether it is present (all pointers are set)
+	base, pointers := indirect(valueType)
+	target := expr
+	if pointers > 0 {
+		target = "(" + strings.Repeat("*", pointers) + expr + ")"
+	}
+	var present []string
+	for i := 0; i < pointers; i++ {
+		present = append(present, strings.Repeat("*", i)+expr+" != nil")
+	}
+
+	omitted := ""
+	if rules.omitEmpty && (rules.omitEmptyFrom < len(rules.rules) || s.file.descends(rules, valueType)) {
+		omitted = s.variable("omitted")
+		fmt.Fprintf(&s.body, "%s := %s\n", omitted, s.file.empty(expr, valueType))
+	}
+
+	for i, rule := range rules.rules {
+		if i == rules.omitEmptyFrom && len(omitted) > 0 {
+			fmt.Fprintf(&s.body, "if !%s {\n", omitted)
+		}
+
+		value := &Value{
+			Expr: target, Type: base, Kind: kindOf(base), key: joinKey(key), rule: rule, label: rules.label, file: s.file,
+		}
+		declared := *value
+		declared.Expr, declared.Type = expr, valueType
+		code, err := s.rule(rule, field, index, value, &declared, present)
+		if err != nil {
+			return err
+		}
+		s.body.WriteString(code)
+	}
+	if len(omitted) > 0 && rules.omitEmptyFrom < len(rules.rules) {
+		s.body.WriteString("}\n")
+	}
+
+	if !s.file.descends(rules, valueType) {
+		return nil
+	}
+	conditions := present
+	if len(omitted) > 0 {
+		conditions = append([]string{"!" + omitted}, present...)
+	}
+	if len(conditions) > 0 {
+		fmt.Fprintf(&s.body, "if %s {\n", strings.Join(conditions, " && "))
+	}
+	if err := s.descend(rules, field, index, expr, valueType, key); err != nil {
+		return err
+	}
+	if len(conditions) > 0 {
+		s.body.WriteString("}\n")
+	}
+	return nil
+}
+
+// rule generates a rule with its generator, or runs it through its validation. Values of interfaces
+// are left to the validations, except for presence rules which receive the declared value.
+func (s *structGen) rule(rule validate.Rule, field *types.Var, index int, value, declared *Value,
+	present []string,
+) (string, error) {
+	// Rules with message options are run by their validations, which render the messages
+	_, isInterface := value.Type.Underlying().(*types.Interface)
+	if generator, ok := s.file.g.rules[rule.Name]; ok && (generator.presence || !isInterface) && !hasMessage(rule) {
+		if generator.presence {
+			value = declared
+		}
+
+		code, err := generator.fn(rule, value)
+		switch {
+		case err == nil && (generator.presence || len(present) == 0):
+			return code, nil
+		case err == nil:
+			return "if " + strings.Join(present, " && ") + " {\n" + code + "}\n", nil
+		case !errors.Is(err, ErrNotGenerated):
+			return "", s.compileError(field, rule.Source, err)
+		}
+	}
+
+	s.fallbacks = append(s.fallbacks, validate.GeneratedRule{
+		Field: field.Name(), Index: index, Rule: rule.Source, Kind: value.Kind, Label: value.label,
+	})
+	return fmt.Sprintf(`if err, field := %s.Validate(%d, %s, obj, prefix); err != nil {
+	if field {
+		err.Key = %s
+	}
+	errors = append(errors, *err)
+}
+`, s.rulesName(), len(s.fallbacks)-1, declared.Expr, value.key), nil
+}
+
+// descend generates the validations of a nested struct, or of the elements of a collection, for
+// the value of the expression (through its pointers, which are set)
+func (s *structGen) descend(rules *ruleSet, field *types.Var, index int, expr string, valueType types.Type,
+	key []string,
+) error {
+	base, pointers := indirect(valueType)
+	target := expr
+	if pointers > 0 {
+		target = "(" + strings.Repeat("*", pointers) + expr + ")"
+	}
+
+	switch underlying := base.Underlying().(type) {
+	case *types.Struct:
+		prefix := joinKey(extendKey(key, `"."`))
+		if rules.promoted {
+			prefix = "prefix"
+		}
+
+		// The struct is validated through a pointer to it
+		pointer := "&" + expr
+		if pointers == 1 {
+			pointer = expr
+		} else if pointers > 1 {
+			pointer = "(" + strings.Repeat("*", pointers-1) + expr + ")"
+		}
+
+		named, isNamed := base.(*types.Named)
+		switch {
+		case isNamed && s.file.generates(named):
+			receiver := expr
+			if pointers > 0 {
+				receiver = pointer
+			}
+			fmt.Fprintf(&s.body, "errors = %s.validateGenerated(errors, %s, &%s)\n", receiver, prefix, s.visit)
+			s.visited = true
+		default:
+			fmt.Fprintf(&s.body, "errors = validate.GeneratedStruct(errors, %s, %s, &%s)\n", pointer, prefix, s.visit)
+			s.visited = true
+		}
+	case *types.Slice, *types.Array:
+		i := s.variable("i")
+		s.file.importName("strconv", "strconv")
+		fmt.Fprintf(&s.body, "for %s := range %s {\n", i, target)
+		if err := s.value(rules.elements, field, index, target+"["+i+"]", elem(base),
+			extendKey(key, `"["`, "strconv.Itoa("+i+")", `"]"`)); err != nil {
+			return err
+		}
+		s.body.WriteString("}\n")
+	case *types.Map:
+		// Maps holding values that lead to structs are visited, which is known once their code is
+		visit, visited, start := s.visit, s.visited, s.body.Len()
+		s.visit, s.visited = s.variable("visit"), false
+		err := s.descendMap(rules, field, index, target, underlying, key)
+		if s.visited {
+			code := s.body.String()[start:]
+			s.body.Truncate(start)
+			fmt.Fprintf(&s.body, "if %s, ok := %s.Visit(%s); ok {\n%s}\n", s.visit, visit, argument(target), code)
+			visited = true
+		}
+		s.visit, s.visited = visit, visited
+		return err
+	}
+	return nil
+}
+
+// descendMap generates the validations of the keys and the elements of a map, for the value of the
+// expression, see descend
+func (s *structGen) descendMap(rules *ruleSet, field *types.Var, index int, target string, mapType *types.Map,
+	key []string,
+) error {
+	keys, names, i, mapKey := s.variable("keys"), s.variable("names"), s.variable("i"), s.variable("key")
+	fmt.Fprintf(&s.body, "%s, %s := validate.GeneratedMapKeys(%s)\n", keys, names, argument(target))
+	fmt.Fprintf(&s.body, "for %s, %s := range %s {\n", i, mapKey, keys)
+	elementKey := extendKey(key, `"["`, names+"["+i+"]", `"]"`)
+	if rules.keys != nil && s.file.active(rules.keys, mapType.Key()) {
+		if err := s.value(rules.keys, field, index, mapKey, mapType.Key(), elementKey); err != nil {
+			return err
+		}
+	}
+	if s.file.active(rules.elements, mapType.Elem()) {
+		element := s.variable("element")
+		fmt.Fprintf(&s.body, "%s := %s[%s]\n", element, target, mapKey)
+		if err := s.value(rules.elements, field, index, element, mapType.Elem(), elementKey); err != nil {
+			return err
+		}
+	}
+	s.body.WriteString("}\n")
+	return nil
+}
+
+// empty returns the expression determining if the value of the expression is empty, as the
+// validate package determines it
+func (f *file) empty(expr string, valueType types.Type) string {
+	generic := "validate.GeneratedIsEmpty(" + expr + ")"
+	switch underlying := valueType.Underlying().(type) {
+	case *types.Basic:
+		info := underlying.Info()
+		switch {
+		case info&types.IsString != 0:
+			return "len(" + expr + ") == 0"
+		case info&types.IsBoolean != 0:
+			return "!" + expr
+		case info&types.IsFloat != 0:
+			return f.importName("math", "math") + ".Float64bits(float64(" + expr + ")) == 0"
+		case info&types.IsInteger != 0:
+			return expr + " == 0"
+		case underlying.Kind() == types.UnsafePointer:
+			return expr + " == nil"
+		}
+		return generic
+	case *types.Slice, *types.Map, *types.Chan:
+		return "len(" + expr + ") == 0"
+	case *types.Signature:
+		return expr + " == nil"
+	case *types.Pointer:
+		base, pointers := indirect(valueType)
+		if _, ok := base.Underlying().(*types.Interface); ok {
+			return generic
+		}
+		conditions := make([]string, pointers)
+		for i := range conditions {
+			conditions[i] = strings.Repeat("*", i) + expr + " == nil"
+		}
+		return strings.Join(conditions, " || ")
+	case *types.Struct:
+		if hasZeroMethod(valueType) {
+			return expr + ".IsZero()"
+		}
+		return generic
+	default:
+		return generic
+	}
+}
+
+// requiredRule generates required, for values that are not interfaces
+func requiredRule(rule validate.Rule, value *Value) (string, error) {
+	if _, ok := value.Type.Underlying().(*types.Interface); ok || rule.Params != nil {
+		return "", ErrNotGenerated
+	}
+	return "if " + value.Empty() + " {\n" + value.Fail("is required") + "}\n", nil
+}
+
+// lengthRule generates min_length (min) or max_length, for strings
+func lengthRule(min bool) RuleGenerator {
+	return func(rule validate.Rule, value *Value) (string, error) {
+		length, err := strconv.ParseInt(rule.Raw, 10, 0)
+		if err != nil || value.Kind != reflect.String {
+			return "", ErrNotGenerated
+		}
+
+		if min {
+			return fmt.Sprintf("if len(%s) < %d {\n%s}\n", argument(value.Expr), length,
+				value.Fail("must be at least "+strconv.Itoa(int(length))+" characters")), nil
+		}
+		return fmt.Sprintf("if len(%s) > %d {\n%s}\n", argument(value.Expr), length,
+			value.Fail("must be no more than "+strconv.Itoa(int(length))+" characters")), nil
+	}
+}
+
+// formatRule generates format for regular expressions, emails are run by their validation
+func formatRule(rule validate.Rule, value *Value) (string, error) {
+	if value.Kind != reflect.String || !strings.Contains(rule.Raw, "regexp:") ||
+		strings.ToLower(rule.Raw) == "email" {
+		return "", ErrNotGenerated
+	}
+	pattern := rule.Raw[strings.Index(rule.Raw, ":")+1:]
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", ErrNotGenerated
+	}
+
+	regexpName := value.Declare(value.Import("regexp") + ".MustCompile(" + strconv.Quote(pattern) + ")")
+	return fmt.Sprintf("if !%s.MatchString(string(%s)) {\n%s}\n", regexpName, argument(value.Expr),
+		value.Fail("does not match regexp format")), nil
+}
+
+// oneOfRule generates one_of, for strings
+func oneOfRule(rule validate.Rule, value *Value) (string, error) {
+	if value.Kind != reflect.String || len(rule.Params) == 0 {
+		return "", ErrNotGenerated
+	}
+
+	conditions := make([]string, len(rule.Params))
+	for i, allowed := range rule.Params {
+		conditions[i] = "string(" + argument(value.Expr) + ") != " + strconv.Quote(allowed)
+	}
+	return "if " + strings.Join(conditions, " && ") + " {\n" +
+		value.FailWith("must be one of "+strings.Join(rule.Params, ", "), "validate.ErrEnumValueNotAllowed") + "}\n", nil
+}
+
+// boundRule generates min (min) or max, for numbers
+func boundRule(min bool) RuleGenerator {
+	return func(rule validate.Rule, value *Value) (string, error) {
+		operator, message := ">", "must be less than or equal to "
+		if min {
+			operator, message = "<", "must be greater than or equal to "
+		}
+
+		var conversion, bound string
+		switch value.Kind { //nolint:exhaustive // other kinds are run by their validation
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			parsed, err := strconv.ParseInt(rule.Raw, 10, 0)
+			if err != nil {
+				return "", ErrNotGenerated
+			}
+			conversion, bound = "int64", strconv.FormatInt(parsed, 10)
+			message += bound
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parsed, err := strconv.ParseUint(rule.Raw, 10, 0)
+			if err != nil {
+				return "", ErrNotGenerated
+			}
+			conversion, bound = "uint64", strconv.FormatUint(parsed, 10)
+			message += bound
+		case reflect.Float32, reflect.Float64:
+			parsed, err := strconv.ParseFloat(rule.Raw, 64)
+			if err != nil || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+				return "", ErrNotGenerated
+			}
+			conversion, bound = "float64", strconv.FormatFloat(parsed, 'g', -1, 64)
+			message += strconv.FormatFloat(parsed, 'E', -1, 64)
+		default:
+			return "", ErrNotGenerated
+		}
+
+		return fmt.Sprintf("if %s(%s) %s %s {\n%s}\n", conversion, argument(value.Expr), operator, bound,
+			value.Fail(message)), nil
+	}
+}
+
+// argument removes the parentheses around an expression used as the argument of a call (e.g. "(*p)")
+func argument(expr string) string {
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return expr
+	}
+
+	// The parentheses must enclose the whole expression, not "(*p).Name()"
+	depth := 0
+	for i := range expr {
+		switch expr[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(expr)-1 {
+				return expr
+			}
+		}
+	}
+	return expr[1 : len(expr)-1]
+}
+
+// isMessageOption determines if the option overrides the message of the rule (message or message.<locale>)
+func isMessageOption(option string) bool {
+	return option == "message" || strings.HasPrefix(option, "message.") && len(option) > len("message.")
+}
+
+// hasMessage determines if the rule has message options
+func hasMessage(rule validate.Rule) bool {
+	for option := range rule.Options {
+		if isMessageOption(option) {
+			return true
+		}
+	}
+	return false
+}
+
+// indirect removes any levels of pointers from the type, returning the number of pointers
+func indirect(t types.Type) (types.Type, int) {
+	pointers := 0
+	for {
+		pointer, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return t, pointers
+		}
+		t, pointers = pointer.Elem(), pointers+1
+	}
+}
+
+// isStruct determines if the type is a struct or a pointer (at any depth) to a struct
+func isStruct(t types.Type) bool {
+	base, _ := indirect(t)
+	_, ok := base.Underlying().(*types.Struct)
+	return ok
+}
+
+// elem gets the type of the elements of a slice, array or map, nil for other types
+func elem(t types.Type) types.Type {
+	switch underlying := t.Underlying().(type) {
+	case *types.Slice:
+		return underlying.Elem()
+	case *types.Array:
+		return underlying.Elem()
+	case *types.Map:
+		return underlying.Elem()
+	default:
+		return nil
+	}
+}
+
+// hasMethod determines if the named type declares the method
+func hasMethod(named *types.Named, name string) bool {
+	for i := 0; i < named.NumMethods(); i++ {
+		if named.Method(i).Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isStructValidator determines if the type (or a pointer to it) implements validate.StructValidator
+func isStructValidator(t types.Type) bool {
+	return hasMethodResult(types.NewPointer(t), "ValidateStruct", "[]"+validatePath+".ValidationError")
+}
+
+// hasZeroMethod determines if the type has an IsZero method with a value receiver (e.g. time.Time)
+func hasZeroMethod(t types.Type) bool {
+	return hasMethodResult(t, "IsZero", "bool")
+}
+
+// hasMethodResult determines if the method set of the type has the method without parameters
+// returning a value of the type named result
+func hasMethodResult(t types.Type, name, result string) bool {
+	method := types.NewMethodSet(t).Lookup(nil, name)
+	if method == nil {
+		return false
+	}
+	signature := method.Type().(*types.Signature)
+	return signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+		types.TypeString(signature.Results().At(0).Type(), nil) == result
+}
+
+// extendKey returns a copy of the parts of a key expression with more parts
+func extendKey(key []string, parts ...string) []string {
+	return append(append(make([]string, 0, len(key)+len(parts)), key...), parts...)
+}
+
+// joinKey joins the parts of a key expression, merging the string literals that follow each other
+func joinKey(key []string) string {
+	merged := make([]string, 0, len(key))
+	for _, part := range key {
+		last := len(merged) - 1
+		if last >= 0 && strings.HasPrefix(part, `"`) && strings.HasPrefix(merged[last], `"`) {
+			previous, _ := strconv.Unquote(merged[last])
+			current, _ := strconv.Unquote(part)
+			merged[last] = strconv.Quote(previous + current)
+			continue
+		}
+		merged = append(merged, part)
+	}
+	return strings.Join(merged, " + ")
+}
+
+// receiverName gets the receiver name of the methods of the type (its first letter)
+func receiverName(named *types.Named) string {
+	return strings.ToLower(named.Obj().Name()[:1])
+}
+
+// kindOf gets the reflect kind of the values of the type
+func kindOf(t types.Type) reflect.Kind {
+	switch underlying := t.Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[underlying.Kind()]
+	case *types.Pointer:
+		return reflect.Pointer
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Array:
+		return reflect.Array
+	case *types.Map:
+		return reflect.Map
+	case *types.Chan:
+		return reflect.Chan
+	case *types.Signature:
+		return reflect.Func
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Interface:
+		return reflect.Interface
+	default:
+		return reflect.Invalid
+	}
+}
+
+// basicKinds are the reflect kinds of the basic types
+var basicKinds = map[types.BasicKind]reflect.Kind{ //nolint:gochecknoglobals // Lookup table of kinds
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// kindName gets the name of the reflect constant of the kind (e.g. "Int64")
+func kindName(kind reflect.Kind) string {
+	switch kind { //nolint:exhaustive // other kinds are named by their strings
+	case reflect.UnsafePointer:
+		return "UnsafePointer"
+	case reflect.Pointer:
+		return "Pointer"
+	case reflect.Invalid:
+		return "Invalid"
+	}
+	name := kind.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// sortedNames gets the keys of the map in order
+func sortedNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
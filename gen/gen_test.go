@@ -0,0 +1,138 @@
+package gen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// TestGenerateUpToDate tests the committed generated files are those the generator generates
+func TestGenerateUpToDate(t *testing.T) {
+	// The directories with the types of their go:generate directives
+	tests := []struct {
+		dir       string
+		typeNames []string
+		names     string
+	}{
+		{"internal/cases", nil, ""},
+		{"internal/named", nil, "json"},
+		{"../examples/generated", []string{"Order"}, ""},
+	}
+
+	for _, test := range tests {
+		dir, names := test.dir, test.names
+		t.Run(dir, func(t *testing.T) {
+			g := NewGenerator()
+			g.SetFieldNameTag(names)
+			source, testSource, err := g.Generate(dir, "validation_gen.go", test.typeNames...)
+			require.NoError(t, err)
+
+			committed, err := os.ReadFile(filepath.Join(dir, "validation_gen.go"))
+			require.NoError(t, err)
+			assert.Equal(t, string(committed), string(source), "run go generate in %s", dir)
+
+			committed, err = os.ReadFile(filepath.Join(dir, "validation_gen_test.go"))
+			require.NoError(t, err)
+			assert.Equal(t, string(committed), string(testSource), "run go generate in %s", dir)
+		})
+	}
+}
+
+// TestGeneratorAddRule tests generating the code of a custom rule
+func TestGeneratorAddRule(t *testing.T) {
+	g := NewGenerator()
+	g.AddRule("upper", func(_ validate.Rule, value *Value) (string, error) {
+		if value.Kind != reflect.String {
+			return "", ErrNotGenerated
+		}
+		return "if " + value.Import("strings") + ".ToUpper(" + value.Expr + ") != " + value.Expr + " {\n" +
+			value.Fail("must be upper case") + "}\n", nil
+	})
+
+	source, _, err := g.Generate("testdata/invalid", "validation_gen.go", "Custom")
+	require.NoError(t, err)
+	assert.Contains(t, string(source), "\t\"strings\"\n")
+	assert.Contains(t, string(source), `if strings.ToUpper(c.Code) != c.Code {`)
+	assert.Contains(t, string(source), `Key: prefix + "Code", Message: "must be upper case", Code: "upper", Value: c.Code}`)
+	assert.NotContains(t, string(source), "NewGeneratedRules")
+
+	// Without its code, the rule is run by its validation
+	source, _, err = NewGenerator().Generate("testdata/invalid", "validation_gen.go", "Custom")
+	require.NoError(t, err)
+	assert.Contains(t, string(source), `validate.GeneratedRule{Field: "Code", Index: 0, Rule: "upper", Kind: reflect.String}`)
+	assert.Contains(t, string(source), `if err, field := customValidationRules.Validate(0, c.Code, obj, prefix); err != nil {`)
+
+	// Errors other than ErrNotGenerated stop the generation
+	errUpper := errors.New("upper takes no parameters")
+	g.AddRule("upper", func(validate.Rule, *Value) (string, error) {
+		return "", errUpper
+	})
+	_, _, err = g.Generate("testdata/invalid", "validation_gen.go", "Custom")
+	require.ErrorIs(t, err, errUpper)
+}
+
+// TestGenerateErrors tests the types the validations cannot be generated for
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		typeName string
+		err      error
+	}{
+		{"Unexported", ErrUnexportedField},
+		{"HasValidate", ErrValidateMethod},
+		{"BadDive", validate.ErrInvalidDive},
+		{"MissingEndKeys", validate.ErrMissingEndKeys},
+		{"UnknownOption", validate.ErrUnknownOption},
+		{"EmptyMessage", validate.ErrMessageRequired},
+		{"Syntax", validate.ErrInvalidSpecification},
+		{"NotStruct", ErrUnknownType},
+		{"Missing", ErrUnknownType},
+	}
+
+	g := NewGenerator()
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			_, _, err := g.Generate("testdata/invalid", "validation_gen.go", test.typeName)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+
+	_, _, err := g.Generate("testdata", "validation_gen.go")
+	require.Error(t, err)
+}
+
+// TestGeneratorMain tests the errors of the command line
+func TestGeneratorMain(t *testing.T) {
+	require.Error(t, NewGenerator().Main([]string{"-unknown"}))
+	require.ErrorIs(t, NewGenerator().Main([]string{"-type", "Missing", "testdata/invalid"}), ErrUnknownType)
+}
+
+// TestArgument tests removing the parentheses around arguments
+func TestArgument(t *testing.T) {
+	tests := map[string]string{
+		"s.Name":          "s.Name",
+		"(*s.Name)":       "*s.Name",
+		"(**s.Name)":      "**s.Name",
+		"(*s.Map)[key]":   "(*s.Map)[key]",
+		"(*s).Name()":     "(*s).Name()",
+		"(*s).Name(x)":    "(*s).Name(x)",
+		"(s.Items[(*i)])": "s.Items[(*i)]",
+	}
+	for expr, expected := range tests {
+		assert.Equal(t, expected, argument(expr), expr)
+	}
+}
+
+// TestJoinKey tests merging the string literals of key expressions
+func TestJoinKey(t *testing.T) {
+	assert.Equal(t, `prefix + "Name"`, joinKey([]string{"prefix", `"Name"`}))
+	assert.Equal(t, `prefix + "Items[" + strconv.Itoa(i1) + "]."`,
+		joinKey([]string{"prefix", `"Items"`, `"["`, "strconv.Itoa(i1)", `"]"`, `"."`}))
+	assert.Equal(t, `prefix + "Tags[\"a\"]"`, joinKey([]string{"prefix", `"Tags"`, `"[\"a\"]"`}))
+}
@@ -0,0 +1,133 @@
+/*
+Package cases has the struct types the generator is tested with, whose generated validations are
+cross-checked with validate.IsValid
+*/
+package cases
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mrz1836/go-validate"
+)
+
+//go:generate go run ../../../cmd/validate-gen -test
+
+// init registers a custom validation, which the generated code runs through validate.DefaultMap
+func init() { //nolint:gochecknoinits // The custom validation is needed by the generated test
+	validate.AddValidation("even_length", func(string, reflect.Kind) (validate.Interface, error) {
+		return &evenLengthValidation{}, nil
+	})
+}
+
+// evenLengthValidation is a custom validation of strings with an even length
+type evenLengthValidation struct {
+	validate.Validation
+}
+
+// Validate tests the length of the string is even
+func (e *evenLengthValidation) Validate(value interface{}, _ reflect.Value) *validate.ValidationError {
+	if text, ok := value.(string); ok && len(text)%2 != 0 {
+		return &validate.ValidationError{Key: e.FieldName(), Message: "must have an even length"}
+	}
+	return nil
+}
+
+// Code is a named string
+type Code string
+
+// Node is a recursive type
+type Node struct {
+	Name     string  `validation:"required even_length"`
+	Children []*Node `validation:"dive"`
+	Parent   *Node
+}
+
+// Graph holds itself through the values of a map, whose copies share the map
+type Graph struct {
+	Name  string           `validation:"required"`
+	Edges map[string]Graph `validation:"dive"`
+}
+
+// Base is embedded by value
+type Base struct {
+	ID      int    `validation:"min=1"`
+	Confirm string `validation:"compare=Label"`
+	Label   string
+}
+
+// Pointers has values through pointers and interfaces
+type Pointers struct {
+	Name     **string     `validation:"required min_length=2"`
+	Count    *int         `validation:"omitempty min=3"`
+	Any      interface{}  `validation:"required"`
+	AnyPtr   *interface{} `validation:"required"`
+	Stringer fmt.Stringer `validation:"omitempty required"`
+	Value    *float32     `validation:"required max=10.5"`
+	Code     *Code        `validation:"one_of=a,b"`
+}
+
+// Collections has dives into slices, arrays and maps
+type Collections struct {
+	Codes  [3]Code             `validation:"dive one_of=a,b"`
+	Matrix [][]int             `validation:"dive omitempty dive min=0 max=9"`
+	ByCode map[Code]*Node      `validation:"omitempty dive keys required max_length=3 endkeys required"`
+	Sets   map[int][]string    `validation:"dive keys max=10 endkeys dive required"`
+	Lookup *map[string]uint8   `validation:"required dive min=1"`
+	Pair   [2]int              `validation:"required"`
+	Bytes  []byte              `validation:"required max_length=3"`
+	Nodes  map[string]Node     `validation:"dive"`
+	Empty  map[string][]string `validation:"dive"`
+}
+
+// Kinds has values of the other kinds
+type Kinds struct {
+	*Node
+	Base
+	Meta struct {
+		Label string `validation:"max_length=3"`
+	}
+	When    time.Time     `validation:"required"`
+	Timeout time.Duration `validation:"min=0 max=1000"`
+	Flag    bool          `validation:"required"`
+	Ratio   float64       `validation:"omitempty min=-1"`
+	Complex complex64     `validation:"required"`
+	Fn      func()        `validation:"required"`
+	Ch      chan int      `validation:"required"`
+	Custom  string        `validation:"even_length"`
+	Group   string        `validation:"required;groups=create"`
+	Number  int           `validation:"min_length=3"`
+	Mixed   Code          `validation:"omitempty min_length=2 format=regexp:^[a-z]+$"`
+	Secret  string        `validation:"omitempty min_length=4;redact"`
+	Birth   string        `validation:"required max_length=10" label:"Date of birth"`
+	Adult   int           `validation:"min=18;message='must be {0} or older';message.es='debe tener {0} años o más'"`
+	Emails  []string      `validation:"dive format=email" label:"Email"`
+}
+
+// Unregistered has a rule of an unknown validation, which is reported for the whole struct
+type Unregistered struct {
+	Name  string `validation:"required"`
+	Other string `validation:"not_registered"`
+}
+
+// Checked validates itself with a value receiver
+type Checked struct {
+	Start int `validation:"min=0"`
+	End   int `validation:"min=0"`
+}
+
+// ValidateStruct checks the start is before the end
+func (c Checked) ValidateStruct() []validate.ValidationError {
+	if c.Start > c.End {
+		return []validate.ValidationError{{Message: "must start before the end"}}
+	}
+	return nil
+}
+
+// Owner has a nested struct validating itself
+type Owner struct {
+	Range   Checked   `validation:"required"`
+	Ranges  []Checked `validation:"dive"`
+	Created time.Time
+}
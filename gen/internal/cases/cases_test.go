@@ -0,0 +1,36 @@
+package cases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// TestGeneratedCycles tests the generated validations stop at cycles of pointers and maps as
+// validate.IsValid does
+func TestGeneratedCycles(t *testing.T) {
+	validate.InitValidations()
+
+	// A node that is its own parent and child
+	node := &Node{Name: "a"}
+	node.Parent, node.Children = node, []*Node{node}
+	ok, errs := node.Validate()
+	assert.False(t, ok)
+	assert.Equal(t, []validate.ValidationError{
+		{Key: "Name", Message: "must have an even length", Code: "even_length", Value: "a"},
+	}, errs)
+	require.NoError(t, validate.CrossCheck(node))
+
+	// A graph whose edge is a copy of itself, sharing the map of edges
+	graph := &Graph{Name: "root", Edges: map[string]Graph{"empty": {}}}
+	graph.Edges["self"] = *graph
+	ok, errs = graph.Validate()
+	assert.False(t, ok)
+	assert.Equal(t, []validate.ValidationError{
+		{Key: "Edges[empty].Name", Message: "is required", Code: "required", Value: ""},
+	}, errs)
+	require.NoError(t, validate.CrossCheck(graph))
+}
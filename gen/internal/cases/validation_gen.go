@@ -0,0 +1,612 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package cases
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// validationValue0 is a value used by the validations
+var validationValue0 = regexp.MustCompile("^[a-z]+$")
+
+// baseValidationRules are the rules of Base run by their validations
+var baseValidationRules = validate.NewGeneratedRules("cases.Base",
+	validate.GeneratedRule{Field: "Confirm", Index: 1, Rule: "compare=Label", Kind: reflect.String},
+)
+
+// collectionsValidationRules are the rules of Collections run by their validations
+var collectionsValidationRules = validate.NewGeneratedRules("cases.Collections",
+	validate.GeneratedRule{Field: "Bytes", Index: 6, Rule: "max_length=3", Kind: reflect.Slice},
+)
+
+// kindsValidationRules are the rules of Kinds run by their validations
+var kindsValidationRules = validate.NewGeneratedRules("cases.Kinds",
+	validate.GeneratedRule{Field: "Custom", Index: 10, Rule: "even_length", Kind: reflect.String},
+	validate.GeneratedRule{Field: "Number", Index: 12, Rule: "min_length=3", Kind: reflect.Int},
+	validate.GeneratedRule{Field: "Adult", Index: 16, Rule: "min=18;message='must be {0} or older';message.es='debe tener {0} años o más'", Kind: reflect.Int},
+	validate.GeneratedRule{Field: "Emails", Index: 17, Rule: "format=email", Kind: reflect.String, Label: "Email"},
+)
+
+// nodeValidationRules are the rules of Node run by their validations
+var nodeValidationRules = validate.NewGeneratedRules("cases.Node",
+	validate.GeneratedRule{Field: "Name", Index: 0, Rule: "even_length", Kind: reflect.String},
+)
+
+// pointersValidationRules are the rules of Pointers run by their validations
+var pointersValidationRules = validate.NewGeneratedRules("cases.Pointers",
+	validate.GeneratedRule{Field: "Any", Index: 2, Rule: "required", Kind: reflect.Interface},
+	validate.GeneratedRule{Field: "Stringer", Index: 4, Rule: "required", Kind: reflect.Interface},
+)
+
+// unregisteredValidationRules are the rules of Unregistered run by their validations
+var unregisteredValidationRules = validate.NewGeneratedRules("cases.Unregistered",
+	validate.GeneratedRule{Field: "Other", Index: 1, Rule: "not_registered", Kind: reflect.String},
+)
+
+// Validate determines if the Base is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (b *Base) Validate() (bool, []validate.ValidationError) {
+	if b == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := b.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Base, prefixing their keys with the path to it
+func (b *Base) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := baseValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(b).Elem()
+
+	// ID
+	if int64(b.ID) < 1 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "ID", Message: "must be greater than or equal to 1", Code: "min", Params: []string{"1"}, Value: b.ID})
+	}
+
+	// Confirm
+	if err, field := baseValidationRules.Validate(0, b.Confirm, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Confirm"
+		}
+		errors = append(errors, *err)
+	}
+	return errors
+}
+
+// Validate determines if the Checked is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (c *Checked) Validate() (bool, []validate.ValidationError) {
+	if c == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := c.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Checked, prefixing their keys with the path to it
+func (c *Checked) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// Start
+	if int64(c.Start) < 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Start", Message: "must be greater than or equal to 0", Code: "min", Params: []string{"0"}, Value: c.Start})
+	}
+
+	// End
+	if int64(c.End) < 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "End", Message: "must be greater than or equal to 0", Code: "min", Params: []string{"0"}, Value: c.End})
+	}
+
+	errors = validate.GeneratedStructErrors(errors, c.ValidateStruct(), prefix)
+	return errors
+}
+
+// Validate determines if the Collections is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (c *Collections) Validate() (bool, []validate.ValidationError) {
+	if c == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := c.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Collections, prefixing their keys with the path to it
+func (c *Collections) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := collectionsValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(c).Elem()
+
+	visit, ok := visited.Visit(c)
+	if !ok {
+		return errors
+	}
+
+	// Codes
+	for i1 := range c.Codes {
+		if string(c.Codes[i1]) != "a" && string(c.Codes[i1]) != "b" {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Codes[" + strconv.Itoa(i1) + "]", Message: "must be one of a, b", Code: "one_of", Params: []string{"a", "b"}, Value: c.Codes[i1], Err: validate.ErrEnumValueNotAllowed})
+		}
+	}
+
+	// Matrix
+	for i2 := range c.Matrix {
+		omitted3 := len(c.Matrix[i2]) == 0
+		if !omitted3 {
+			for i4 := range c.Matrix[i2] {
+				if int64(c.Matrix[i2][i4]) < 0 {
+					errors = append(errors, validate.ValidationError{Key: prefix + "Matrix[" + strconv.Itoa(i2) + "][" + strconv.Itoa(i4) + "]", Message: "must be greater than or equal to 0", Code: "min", Params: []string{"0"}, Value: c.Matrix[i2][i4]})
+				}
+				if int64(c.Matrix[i2][i4]) > 9 {
+					errors = append(errors, validate.ValidationError{Key: prefix + "Matrix[" + strconv.Itoa(i2) + "][" + strconv.Itoa(i4) + "]", Message: "must be less than or equal to 9", Code: "max", Params: []string{"9"}, Value: c.Matrix[i2][i4]})
+				}
+			}
+		}
+	}
+
+	// ByCode
+	omitted5 := len(c.ByCode) == 0
+	if !omitted5 {
+		if visit6, ok := visit.Visit(c.ByCode); ok {
+			keys7, names8 := validate.GeneratedMapKeys(c.ByCode)
+			for i9, key10 := range keys7 {
+				if len(key10) == 0 {
+					errors = append(errors, validate.ValidationError{Key: prefix + "ByCode[" + names8[i9] + "]", Message: "is required", Code: "required", Value: key10})
+				}
+				if len(key10) > 3 {
+					errors = append(errors, validate.ValidationError{Key: prefix + "ByCode[" + names8[i9] + "]", Message: "must be no more than 3 characters", Code: "max_length", Params: []string{"3"}, Value: key10})
+				}
+				element11 := c.ByCode[key10]
+				if element11 == nil {
+					errors = append(errors, validate.ValidationError{Key: prefix + "ByCode[" + names8[i9] + "]", Message: "is required", Code: "required", Value: element11})
+				}
+				if element11 != nil {
+					errors = element11.validateGenerated(errors, prefix+"ByCode["+names8[i9]+"].", &visit6)
+				}
+			}
+		}
+	}
+
+	// Sets
+	keys13, names14 := validate.GeneratedMapKeys(c.Sets)
+	for i15, key16 := range keys13 {
+		if int64(key16) > 10 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Sets[" + names14[i15] + "]", Message: "must be less than or equal to 10", Code: "max", Params: []string{"10"}, Value: key16})
+		}
+		element17 := c.Sets[key16]
+		for i18 := range element17 {
+			if len(element17[i18]) == 0 {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Sets[" + names14[i15] + "][" + strconv.Itoa(i18) + "]", Message: "is required", Code: "required", Value: element17[i18]})
+			}
+		}
+	}
+
+	// Lookup
+	if c.Lookup == nil {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Lookup", Message: "is required", Code: "required", Value: c.Lookup})
+	}
+	if c.Lookup != nil {
+		keys20, names21 := validate.GeneratedMapKeys(*c.Lookup)
+		for i22, key23 := range keys20 {
+			element24 := (*c.Lookup)[key23]
+			if uint64(element24) < 1 {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Lookup[" + names21[i22] + "]", Message: "must be greater than or equal to 1", Code: "min", Params: []string{"1"}, Value: element24})
+			}
+		}
+	}
+
+	// Pair
+	if validate.GeneratedIsEmpty(c.Pair) {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Pair", Message: "is required", Code: "required", Value: c.Pair})
+	}
+
+	// Bytes
+	if len(c.Bytes) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Bytes", Message: "is required", Code: "required", Value: c.Bytes})
+	}
+	if err, field := collectionsValidationRules.Validate(0, c.Bytes, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Bytes"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Nodes
+	if visit25, ok := visit.Visit(c.Nodes); ok {
+		keys26, names27 := validate.GeneratedMapKeys(c.Nodes)
+		for i28, key29 := range keys26 {
+			element30 := c.Nodes[key29]
+			errors = element30.validateGenerated(errors, prefix+"Nodes["+names27[i28]+"].", &visit25)
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Graph is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (g *Graph) Validate() (bool, []validate.ValidationError) {
+	if g == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := g.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Graph, prefixing their keys with the path to it
+func (g *Graph) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	visit, ok := visited.Visit(g)
+	if !ok {
+		return errors
+	}
+
+	// Name
+	if len(g.Name) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Name", Message: "is required", Code: "required", Value: g.Name})
+	}
+
+	// Edges
+	if visit1, ok := visit.Visit(g.Edges); ok {
+		keys2, names3 := validate.GeneratedMapKeys(g.Edges)
+		for i4, key5 := range keys2 {
+			element6 := g.Edges[key5]
+			errors = element6.validateGenerated(errors, prefix+"Edges["+names3[i4]+"].", &visit1)
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Kinds is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (k *Kinds) Validate() (bool, []validate.ValidationError) {
+	if k == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := k.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Kinds, prefixing their keys with the path to it
+func (k *Kinds) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := kindsValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(k).Elem()
+
+	visit, ok := visited.Visit(k)
+	if !ok {
+		return errors
+	}
+
+	// Node
+	if k.Node != nil {
+		errors = k.Node.validateGenerated(errors, prefix, &visit)
+	}
+
+	// Base
+	errors = k.Base.validateGenerated(errors, prefix, &visit)
+
+	// Meta
+	errors = validate.GeneratedStruct(errors, &k.Meta, prefix+"Meta.", &visit)
+
+	// When
+	if k.When.IsZero() {
+		errors = append(errors, validate.ValidationError{Key: prefix + "When", Message: "is required", Code: "required", Value: k.When})
+	}
+
+	// Timeout
+	if int64(k.Timeout) < 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Timeout", Message: "must be greater than or equal to 0", Code: "min", Params: []string{"0"}, Value: k.Timeout})
+	}
+	if int64(k.Timeout) > 1000 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Timeout", Message: "must be less than or equal to 1000", Code: "max", Params: []string{"1000"}, Value: k.Timeout})
+	}
+
+	// Flag
+	if !k.Flag {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Flag", Message: "is required", Code: "required", Value: k.Flag})
+	}
+
+	// Ratio
+	omitted1 := math.Float64bits(float64(k.Ratio)) == 0
+	if !omitted1 {
+		if float64(k.Ratio) < -1 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Ratio", Message: "must be greater than or equal to -1E+00", Code: "min", Params: []string{"-1"}, Value: k.Ratio})
+		}
+	}
+
+	// Complex
+	if validate.GeneratedIsEmpty(k.Complex) {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Complex", Message: "is required", Code: "required", Value: k.Complex})
+	}
+
+	// Fn
+	if k.Fn == nil {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Fn", Message: "is required", Code: "required", Value: k.Fn})
+	}
+
+	// Ch
+	if len(k.Ch) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Ch", Message: "is required", Code: "required", Value: k.Ch})
+	}
+
+	// Custom
+	if err, field := kindsValidationRules.Validate(0, k.Custom, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Custom"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Number
+	if err, field := kindsValidationRules.Validate(1, k.Number, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Number"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Mixed
+	omitted2 := len(k.Mixed) == 0
+	if !omitted2 {
+		if len(k.Mixed) < 2 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Mixed", Message: "must be at least 2 characters", Code: "min_length", Params: []string{"2"}, Value: k.Mixed})
+		}
+		if !validationValue0.MatchString(string(k.Mixed)) {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Mixed", Message: "does not match regexp format", Code: "format", Params: []string{"regexp:^[a-z]+$"}, Value: k.Mixed})
+		}
+	}
+
+	// Secret
+	omitted3 := len(k.Secret) == 0
+	if !omitted3 {
+		if len(k.Secret) < 4 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Secret", Message: "must be at least 4 characters", Code: "min_length", Params: []string{"4"}})
+		}
+	}
+
+	// Birth
+	if len(k.Birth) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Birth", Label: "Date of birth", Message: "is required", Code: "required", Value: k.Birth})
+	}
+	if len(k.Birth) > 10 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Birth", Label: "Date of birth", Message: "must be no more than 10 characters", Code: "max_length", Params: []string{"10"}, Value: k.Birth})
+	}
+
+	// Adult
+	if err, field := kindsValidationRules.Validate(2, k.Adult, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Adult"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Emails
+	for i4 := range k.Emails {
+		if err, field := kindsValidationRules.Validate(3, k.Emails[i4], obj, prefix); err != nil {
+			if field {
+				err.Key = prefix + "Emails[" + strconv.Itoa(i4) + "]"
+			}
+			errors = append(errors, *err)
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Node is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (n *Node) Validate() (bool, []validate.ValidationError) {
+	if n == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := n.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Node, prefixing their keys with the path to it
+func (n *Node) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := nodeValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(n).Elem()
+
+	visit, ok := visited.Visit(n)
+	if !ok {
+		return errors
+	}
+
+	// Name
+	if len(n.Name) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Name", Message: "is required", Code: "required", Value: n.Name})
+	}
+	if err, field := nodeValidationRules.Validate(0, n.Name, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Name"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Children
+	for i1 := range n.Children {
+		if n.Children[i1] != nil {
+			errors = n.Children[i1].validateGenerated(errors, prefix+"Children["+strconv.Itoa(i1)+"].", &visit)
+		}
+	}
+
+	// Parent
+	if n.Parent != nil {
+		errors = n.Parent.validateGenerated(errors, prefix+"Parent.", &visit)
+	}
+	return errors
+}
+
+// Validate determines if the Owner is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (o *Owner) Validate() (bool, []validate.ValidationError) {
+	if o == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := o.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Owner, prefixing their keys with the path to it
+func (o *Owner) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	visit, ok := visited.Visit(o)
+	if !ok {
+		return errors
+	}
+
+	// Range
+	if validate.GeneratedIsEmpty(o.Range) {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Range", Message: "is required", Code: "required", Value: o.Range})
+	}
+	errors = o.Range.validateGenerated(errors, prefix+"Range.", &visit)
+
+	// Ranges
+	for i1 := range o.Ranges {
+		errors = o.Ranges[i1].validateGenerated(errors, prefix+"Ranges["+strconv.Itoa(i1)+"].", &visit)
+	}
+	return errors
+}
+
+// Validate determines if the Pointers is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (p *Pointers) Validate() (bool, []validate.ValidationError) {
+	if p == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := p.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Pointers, prefixing their keys with the path to it
+func (p *Pointers) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := pointersValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(p).Elem()
+
+	// Name
+	if p.Name == nil || *p.Name == nil {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Name", Message: "is required", Code: "required", Value: p.Name})
+	}
+	if p.Name != nil && *p.Name != nil {
+		if len(**p.Name) < 2 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Name", Message: "must be at least 2 characters", Code: "min_length", Params: []string{"2"}, Value: **p.Name})
+		}
+	}
+
+	// Count
+	omitted1 := p.Count == nil
+	if !omitted1 {
+		if p.Count != nil {
+			if int64(*p.Count) < 3 {
+				errors = append(errors, validate.ValidationError{Key: prefix + "Count", Message: "must be greater than or equal to 3", Code: "min", Params: []string{"3"}, Value: *p.Count})
+			}
+		}
+	}
+
+	// Any
+	if err, field := pointersValidationRules.Validate(0, p.Any, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Any"
+		}
+		errors = append(errors, *err)
+	}
+
+	// AnyPtr
+	if validate.GeneratedIsEmpty(p.AnyPtr) {
+		errors = append(errors, validate.ValidationError{Key: prefix + "AnyPtr", Message: "is required", Code: "required", Value: p.AnyPtr})
+	}
+
+	// Stringer
+	omitted2 := validate.GeneratedIsEmpty(p.Stringer)
+	if !omitted2 {
+		if err, field := pointersValidationRules.Validate(1, p.Stringer, obj, prefix); err != nil {
+			if field {
+				err.Key = prefix + "Stringer"
+			}
+			errors = append(errors, *err)
+		}
+	}
+
+	// Value
+	if p.Value == nil {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Value", Message: "is required", Code: "required", Value: p.Value})
+	}
+	if p.Value != nil {
+		if float64(*p.Value) > 10.5 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Value", Message: "must be less than or equal to 1.05E+01", Code: "max", Params: []string{"10.5"}, Value: *p.Value})
+		}
+	}
+
+	// Code
+	if p.Code != nil {
+		if string(*p.Code) != "a" && string(*p.Code) != "b" {
+			errors = append(errors, validate.ValidationError{Key: prefix + "Code", Message: "must be one of a, b", Code: "one_of", Params: []string{"a", "b"}, Value: *p.Code, Err: validate.ErrEnumValueNotAllowed})
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Unregistered is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (u *Unregistered) Validate() (bool, []validate.ValidationError) {
+	if u == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := u.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Unregistered, prefixing their keys with the path to it
+func (u *Unregistered) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := unregisteredValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(u).Elem()
+
+	// Name
+	if len(u.Name) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Name", Message: "is required", Code: "required", Value: u.Name})
+	}
+
+	// Other
+	if err, field := unregisteredValidationRules.Validate(0, u.Other, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "Other"
+		}
+		errors = append(errors, *err)
+	}
+	return errors
+}
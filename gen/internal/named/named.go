@@ -0,0 +1,43 @@
+/*
+Package named has the struct types the generator is tested with when the fields are named by their
+json tags, whose generated validations are cross-checked with validate.IsValid
+*/
+package named
+
+//go:generate go run ../../../cmd/validate-gen -names json -test
+
+// Address is nested in Signup
+type Address struct {
+	City string `json:"city" validation:"required"`
+	Zip  string `json:"zip,omitempty" validation:"omitempty min_length=5"`
+}
+
+// Audit is embedded in Signup without a json name, so its fields are promoted
+type Audit struct {
+	Source string `json:"source" validation:"max_length=8"`
+}
+
+// Owner is embedded in Signup with a json name
+type Owner struct {
+	Name string `json:"name" validation:"required"`
+}
+
+// Signup has fields named by their json tags, and fields falling back to their Go names
+type Signup struct {
+	Audit
+	Owner `json:"owner"`
+
+	Email     string    `json:"email" validation:"required format=email"`
+	Password  string    `json:"password" validation:"min_length=8"`
+	Confirm   string    `json:"password_confirmation" validation:"compare=Password"`
+	Nick      string    `json:"-" validation:"max_length=10"`
+	Dash      string    `json:"-," validation:"max_length=10"`
+	Plain     string    `json:",omitempty" validation:"max_length=10"`
+	Country   string    `json:"country"`
+	State     string    `json:"state" validation:"required_if=Country,US"`
+	Start     int       `json:"start"`
+	End       int       `json:"end" validation:"gte_field=Start"`
+	Address   *Address  `json:"address"`
+	Addresses []Address `json:"addresses" validation:"dive"`
+	Tags      []string  `json:"tags" validation:"dive min_length=2"`
+}
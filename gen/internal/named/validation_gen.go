@@ -0,0 +1,194 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package named
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// signupValidationRules are the rules of Signup run by their validations
+var signupValidationRules = validate.NewGeneratedRules("named.Signup",
+	validate.GeneratedRule{Field: "Email", Index: 2, Rule: "format=email", Kind: reflect.String},
+	validate.GeneratedRule{Field: "Confirm", Index: 4, Rule: "compare=Password", Kind: reflect.String},
+	validate.GeneratedRule{Field: "State", Index: 9, Rule: "required_if=Country,US", Kind: reflect.String},
+	validate.GeneratedRule{Field: "End", Index: 11, Rule: "gte_field=Start", Kind: reflect.Int},
+)
+
+// Validate determines if the Address is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (a *Address) Validate() (bool, []validate.ValidationError) {
+	if a == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := a.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Address, prefixing their keys with the path to it
+func (a *Address) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// City
+	if len(a.City) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "city", Message: "is required", Code: "required", Value: a.City})
+	}
+
+	// Zip
+	omitted1 := len(a.Zip) == 0
+	if !omitted1 {
+		if len(a.Zip) < 5 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "zip", Message: "must be at least 5 characters", Code: "min_length", Params: []string{"5"}, Value: a.Zip})
+		}
+	}
+	return errors
+}
+
+// Validate determines if the Audit is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (a *Audit) Validate() (bool, []validate.ValidationError) {
+	if a == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := a.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Audit, prefixing their keys with the path to it
+func (a *Audit) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// Source
+	if len(a.Source) > 8 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "source", Message: "must be no more than 8 characters", Code: "max_length", Params: []string{"8"}, Value: a.Source})
+	}
+	return errors
+}
+
+// Validate determines if the Owner is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (o *Owner) Validate() (bool, []validate.ValidationError) {
+	if o == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := o.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Owner, prefixing their keys with the path to it
+func (o *Owner) validateGenerated(errors []validate.ValidationError, prefix string,
+	_ *validate.GeneratedVisit,
+) []validate.ValidationError {
+	// Name
+	if len(o.Name) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "name", Message: "is required", Code: "required", Value: o.Name})
+	}
+	return errors
+}
+
+// Validate determines if the Signup is valid based on its validation tags, as validate.IsValid
+// does without reflection
+func (s *Signup) Validate() (bool, []validate.ValidationError) {
+	if s == nil {
+		return false, []validate.ValidationError{{Message: validate.ErrNotStruct.Error(), Err: validate.ErrNotStruct}}
+	}
+	errors := s.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated appends the errors of the Signup, prefixing their keys with the path to it
+func (s *Signup) validateGenerated(errors []validate.ValidationError, prefix string,
+	visited *validate.GeneratedVisit,
+) []validate.ValidationError {
+	errors, ok := signupValidationRules.Compile(errors, prefix)
+	if !ok {
+		return errors
+	}
+	obj := reflect.ValueOf(s).Elem()
+
+	visit, ok := visited.Visit(s)
+	if !ok {
+		return errors
+	}
+
+	// Audit
+	errors = s.Audit.validateGenerated(errors, prefix, &visit)
+
+	// Owner
+	errors = s.Owner.validateGenerated(errors, prefix+"owner.", &visit)
+
+	// Email
+	if len(s.Email) == 0 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "email", Message: "is required", Code: "required", Value: s.Email})
+	}
+	if err, field := signupValidationRules.Validate(0, s.Email, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "email"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Password
+	if len(s.Password) < 8 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "password", Message: "must be at least 8 characters", Code: "min_length", Params: []string{"8"}, Value: s.Password})
+	}
+
+	// Confirm
+	if err, field := signupValidationRules.Validate(1, s.Confirm, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "password_confirmation"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Nick
+	if len(s.Nick) > 10 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Nick", Message: "must be no more than 10 characters", Code: "max_length", Params: []string{"10"}, Value: s.Nick})
+	}
+
+	// Dash
+	if len(s.Dash) > 10 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "-", Message: "must be no more than 10 characters", Code: "max_length", Params: []string{"10"}, Value: s.Dash})
+	}
+
+	// Plain
+	if len(s.Plain) > 10 {
+		errors = append(errors, validate.ValidationError{Key: prefix + "Plain", Message: "must be no more than 10 characters", Code: "max_length", Params: []string{"10"}, Value: s.Plain})
+	}
+
+	// State
+	if err, field := signupValidationRules.Validate(2, s.State, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "state"
+		}
+		errors = append(errors, *err)
+	}
+
+	// End
+	if err, field := signupValidationRules.Validate(3, s.End, obj, prefix); err != nil {
+		if field {
+			err.Key = prefix + "end"
+		}
+		errors = append(errors, *err)
+	}
+
+	// Address
+	if s.Address != nil {
+		errors = s.Address.validateGenerated(errors, prefix+"address.", &visit)
+	}
+
+	// Addresses
+	for i1 := range s.Addresses {
+		errors = s.Addresses[i1].validateGenerated(errors, prefix+"addresses["+strconv.Itoa(i1)+"].", &visit)
+	}
+
+	// Tags
+	for i2 := range s.Tags {
+		if len(s.Tags[i2]) < 2 {
+			errors = append(errors, validate.ValidationError{Key: prefix + "tags[" + strconv.Itoa(i2) + "]", Message: "must be at least 2 characters", Code: "min_length", Params: []string{"2"}, Value: s.Tags[i2]})
+		}
+	}
+	return errors
+}
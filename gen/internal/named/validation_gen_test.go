@@ -0,0 +1,32 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package named
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mrz1836/go-validate"
+)
+
+// TestGeneratedValidations cross-checks the generated validations with validate.IsValid, for the
+// zero value and random values of each type
+func TestGeneratedValidations(t *testing.T) {
+	validate.InitValidations()
+	validate.SetFieldNames(validate.TagFieldNames("json"))
+
+	random := rand.New(rand.NewSource(1))
+	for _, object := range []validate.GeneratedValidator{
+		&Address{},
+		&Audit{},
+		&Owner{},
+		&Signup{},
+	} {
+		if err := validate.CrossCheck(object); err != nil {
+			t.Error(err)
+		}
+		if err := validate.CrossCheckRandom(object, random, 1000); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package invalid has the struct types the generator cannot generate the validations of
+package invalid
+
+// Unexported has a validation tag on an unexported field
+type Unexported struct {
+	name string `validation:"required"`
+}
+
+// HasValidate already has a Validate method
+type HasValidate struct {
+	Name string `validation:"required"`
+}
+
+// Validate is not generated
+func (h *HasValidate) Validate() bool {
+	return len(h.Name) > 0
+}
+
+// BadDive dives into a string
+type BadDive struct {
+	Name string `validation:"dive required"`
+}
+
+// MissingEndKeys does not close its keys
+type MissingEndKeys struct {
+	Names map[string]string `validation:"dive keys required"`
+}
+
+// UnknownOption has a rule with an unknown option
+type UnknownOption struct {
+	Name string `validation:"required;when=create"`
+}
+
+// EmptyMessage has a rule with an empty message option
+type EmptyMessage struct {
+	Name string `validation:"required;message="`
+}
+
+// Syntax has a malformed tag
+type Syntax struct {
+	Name string `validation:"format='regexp:^a"`
+}
+
+// Custom has a custom rule
+type Custom struct {
+	Code string `validation:"upper"`
+}
+
+// NotStruct is not a struct
+type NotStruct string
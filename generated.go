@@ -0,0 +1,334 @@
+package validate
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GeneratedValidator is implemented by structs with a Validate method generated by validate-gen
+// (see cmd/validate-gen), which runs the validations of the tags without reflection and returns
+// the same result as IsValid
+type GeneratedValidator interface {
+	// Validate determines if the struct is valid
+	Validate() (bool, []ValidationError)
+}
+
+// GeneratedRule is a rule run by the generated code through its validation in DefaultMap, for
+// rules the generator has no code for (e.g. custom validations registered with AddValidation)
+type GeneratedRule struct {
+	// Field is the name of the field
+	Field string
+
+	// Index is the field index location
+	Index int
+
+	// Rule is the rule as written in the tag (e.g. "format=email")
+	Rule string
+
+	// Kind is the kind of the value the rule is applied to
+	Kind reflect.Kind
+
+	// Label is the label of the field in messages (see the label tag), if any
+	Label string
+}
+
+// GeneratedRules are the rules of a struct run by the generated code through their validations,
+// which are built from DefaultMap on first use, as IsValid builds them
+type GeneratedRules struct {
+	// structName is the name of the struct type in compile errors (e.g. "model.Customer")
+	structName string
+
+	// rules are the rules in the order IsValid builds them
+	rules []GeneratedRule
+
+	// validations are the validations of the rules, and parsed their parsed rules
+	validations []Interface
+	parsed      []Rule
+
+	// err is set when a rule could not be built
+	err *CompileError
+
+	// once builds the validations
+	once sync.Once
+}
+
+// NewGeneratedRules creates the rules of a struct for the generated code
+func NewGeneratedRules(structName string, rules ...GeneratedRule) *GeneratedRules {
+	return &GeneratedRules{structName: structName, rules: rules}
+}
+
+// build builds the validations of the rules, stopping at the first that cannot be built. The
+// other fields are named in the errors as DefaultMap names the fields (see SetFieldNames).
+func (g *GeneratedRules) build() {
+	names := DefaultMap.fieldNaming()
+	g.validations = make([]Interface, len(g.rules))
+	g.parsed = make([]Rule, len(g.rules))
+	for i, generated := range g.rules {
+		parsed, err := ParseTag(generated.Rule)
+		if err == nil && len(parsed) != 1 {
+			err = ErrInvalidSpecification
+		}
+		if err != nil {
+			g.err = &CompileError{Struct: g.structName, Field: generated.Field, Tag: generated.Rule, Err: err}
+			return
+		}
+
+		rule := parsed[0]
+		builder, ok := DefaultMap.validationNameToBuilder.Load(rule.Name)
+		if !ok || builder == nil {
+			g.err = &CompileError{
+				Struct: g.structName, Field: generated.Field, Tag: rule.Source,
+				Err: fmt.Errorf("%w: %s", ErrUnknownValidation, rule.Name),
+			}
+			return
+		}
+		validation, err := builder.(RuleBuilder)(rule, generated.Kind)
+		if err != nil {
+			g.err = &CompileError{Struct: g.structName, Field: generated.Field, Tag: rule.Source, Err: err}
+			return
+		}
+
+		validation.SetFieldName(generated.Field)
+		validation.SetFieldIndex(generated.Index)
+		if namer, ok := validation.(fieldsNamer); ok && names != nil {
+			namer.setFieldNames(names)
+		}
+		g.validations[i], g.parsed[i] = validation, rule
+	}
+}
+
+// Compile builds the validations on first use. When a rule cannot be built the struct is not
+// validated, and the error is appended for the struct at the path of the prefix, as IsValid does.
+func (g *GeneratedRules) Compile(errors []ValidationError, prefix string) ([]ValidationError, bool) {
+	g.once.Do(g.build)
+	if g.err != nil {
+		return append(errors, g.err.validationError(prefix)), false
+	}
+	return errors, true
+}
+
+// Validate runs the validation of the rule i on a field value (as declared, e.g. a pointer) of
+// the struct obj. Presence validations (e.g. required) receive the value as is, and the others
+// the value pointers lead to, and are skipped for nil values. The returned flag is set when the
+// error is about the field itself, for the caller to key it with the path of the value, while
+// errors about other fields (e.g. a compare field) are keyed with the prefix.
+func (g *GeneratedRules) Validate(i int, value interface{}, obj reflect.Value, prefix string,
+) (*ValidationError, bool) {
+	validation := g.validations[i]
+	if _, ok := validation.(PresenceValidation); !ok {
+		target, absent := indirectValue(reflect.ValueOf(value))
+		if absent || !target.IsValid() {
+			return nil, false
+		}
+		value = target.Interface()
+	}
+
+	err := validation.Validate(value, obj)
+	if err == nil {
+		return nil, false
+	}
+	err.describe(&g.parsed[i], validation.FieldName(), g.rules[i].Label, reflect.ValueOf(value))
+	if err.Key == validation.FieldName() {
+		return err, true
+	}
+	err.Key = prefix + err.Key
+	return err, false
+}
+
+// GeneratedIsEmpty determines if a value is empty as omitempty and required do, for the
+// values the generated code cannot test directly (e.g. arrays and interfaces)
+func GeneratedIsEmpty[T any](value T) bool {
+	return isEmpty(reflect.ValueOf(&value).Elem())
+}
+
+// GeneratedMapKeys gets the keys of a map with their printed names, sorted by name as IsValid
+// reports the errors of the elements
+func GeneratedMapKeys[K comparable, V any](m map[K]V) ([]K, []string) {
+	keys := make([]K, 0, len(m))
+	names := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+		names = append(names, fmt.Sprint(key))
+	}
+	sort.Sort(generatedKeySorter[K]{keys: keys, names: names})
+	return keys, names
+}
+
+// generatedKeySorter sorts map keys by their printed names
+type generatedKeySorter[K comparable] struct {
+	keys  []K
+	names []string
+}
+
+func (s generatedKeySorter[K]) Len() int           { return len(s.keys) }
+func (s generatedKeySorter[K]) Less(i, j int) bool { return s.names[i] < s.names[j] }
+func (s generatedKeySorter[K]) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+}
+
+// GeneratedVisit is a struct or a map being validated by the generated code, linked to the
+// visits of the values holding it, so cycles of pointers stop as they do for IsValid
+type GeneratedVisit struct {
+	// ref identifies the struct or map, the zero reference for values that are not addressable
+	ref reference
+
+	// parent is the visit of the value holding it, nil for the validated object
+	parent *GeneratedVisit
+}
+
+// Visit visits a struct (through a pointer to it) or a map held by the visited value (nil for
+// the validated object itself), returning false when it is already being validated, as found
+// through a cycle of pointers (e.g. a child pointing back to its parent)
+func (v *GeneratedVisit) Visit(object interface{}) (GeneratedVisit, bool) {
+	value := reflect.ValueOf(object)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	ref, ok := referenceOf(value)
+	if ok {
+		for visit := v; visit != nil; visit = visit.parent {
+			if visit.ref == ref {
+				return GeneratedVisit{}, false
+			}
+		}
+	}
+	return GeneratedVisit{ref: ref, parent: v}, true
+}
+
+// GeneratedStruct validates a nested struct (a pointer to it) that has no generated code, such as
+// a struct of another package, prefixing the error keys with the path to the struct. The visited
+// structs and maps holding it are not validated again when it leads back to them.
+func GeneratedStruct(errors []ValidationError, object interface{}, prefix string, visited *GeneratedVisit,
+) []ValidationError {
+	root := getPath(prefix)
+	for visit := visited; visit != nil; visit = visit.parent {
+		if visit.ref != (reference{}) {
+			root.stack.references = append(root.stack.references, visit.ref)
+		}
+	}
+	errors = DefaultMap.validateStruct(errors, reflect.ValueOf(object).Elem(), root, runMode{})
+	putPath(root)
+	return errors
+}
+
+// GeneratedStructErrors appends the errors of a struct's ValidateStruct (see StructValidator)
+// with their keys prefixed by the path to the struct
+func GeneratedStructErrors(errors, structErrors []ValidationError, prefix string) []ValidationError {
+	for _, err := range structErrors {
+		if len(err.Key) == 0 {
+			err.Key = strings.TrimSuffix(prefix, ".")
+		} else {
+			err.Key = prefix + err.Key
+		}
+		errors = append(errors, err)
+	}
+	return errors
+}
+
+// CrossCheck validates the object with its generated Validate method and with IsValid, returning
+// an error describing both results when they differ. The validations must be registered first.
+func CrossCheck(object GeneratedValidator) error {
+	generatedOK, generatedErrors := object.Validate()
+	ok, errors := IsValid(object)
+
+	// Both results have no errors (nil or empty), or the same errors
+	same := len(generatedErrors) == 0 && len(errors) == 0 || reflect.DeepEqual(generatedErrors, errors)
+	if generatedOK != ok || !same {
+		return fmt.Errorf("%w: %T %+v: generated %v %+v, IsValid %v %+v", ErrGeneratedMismatch,
+			object, object, generatedOK, generatedErrors, ok, errors)
+	}
+	return nil
+}
+
+// CrossCheckRandom runs CrossCheck on count values of the object's type (a pointer to a struct)
+// with random exported fields, returning the error of the first value with different results
+func CrossCheckRandom(object GeneratedValidator, random *rand.Rand, count int) error {
+	objectType := reflect.TypeOf(object)
+	if objectType.Kind() != reflect.Pointer || objectType.Elem().Kind() != reflect.Struct {
+		return &CompileError{Struct: objectType.String(), Err: ErrNotStruct}
+	}
+
+	for i := 0; i < count; i++ {
+		value := reflect.New(objectType.Elem())
+		randomValue(value.Elem(), random, 0)
+		if err := CrossCheck(value.Interface().(GeneratedValidator)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Characters of random strings, the maximum length of random strings and the depth of random values
+const (
+	randomCharacters = "aZ09 @.-_"
+	randomMaxLength  = 12
+	randomMaxDepth   = 4
+)
+
+// randomValue sets a settable value to a random value, favoring empty values and small numbers
+// (around the bounds rules usually have). Interfaces, channels and functions are left nil.
+func randomValue(value reflect.Value, random *rand.Rand, depth int) {
+	if depth > randomMaxDepth || random.Intn(5) == 0 {
+		return
+	}
+
+	switch value.Kind() { //nolint:exhaustive // other kinds are left empty
+	case reflect.Bool:
+		value.SetBool(random.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(random.Intn(41)-20) * randomScale(random))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(uint64(random.Intn(41) * int(randomScale(random))))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(float64(random.Intn(81)-40) / 2 * float64(randomScale(random)))
+	case reflect.String:
+		characters := make([]byte, random.Intn(randomMaxLength+1))
+		for i := range characters {
+			characters[i] = randomCharacters[random.Intn(len(randomCharacters))]
+		}
+		value.SetString(string(characters))
+	case reflect.Pointer:
+		pointer := reflect.New(value.Type().Elem())
+		randomValue(pointer.Elem(), random, depth+1)
+		value.Set(pointer)
+	case reflect.Slice:
+		slice := reflect.MakeSlice(value.Type(), random.Intn(4), random.Intn(4)+4)
+		for i := 0; i < slice.Len(); i++ {
+			randomValue(slice.Index(i), random, depth+1)
+		}
+		value.Set(slice)
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			randomValue(value.Index(i), random, depth+1)
+		}
+	case reflect.Map:
+		m := reflect.MakeMap(value.Type())
+		for i := random.Intn(4); i > 0; i-- {
+			key, element := reflect.New(value.Type().Key()).Elem(), reflect.New(value.Type().Elem()).Elem()
+			randomValue(key, random, depth+1)
+			randomValue(element, random, depth+1)
+			m.SetMapIndex(key, element)
+		}
+		value.Set(m)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Field(i).CanSet() {
+				randomValue(value.Field(i), random, depth+1)
+			}
+		}
+	}
+}
+
+// randomScale scales a quarter of the random numbers up, to go past larger bounds
+func randomScale(random *rand.Rand) int64 {
+	if random.Intn(4) == 0 {
+		return int64(random.Intn(1000))
+	}
+	return 1
+}
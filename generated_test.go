@@ -0,0 +1,228 @@
+package validate
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generatedAccount is a struct with a hand-written "generated" Validate method
+type generatedAccount struct {
+	Name    string  `validation:"required min_length=3"`
+	Confirm string  `validation:"compare=Name"`
+	Nick    *string `validation:"required"`
+
+	// broken makes Validate return a different result than IsValid
+	broken bool
+}
+
+// Validate validates the account as the generated code would
+func (g *generatedAccount) Validate() (bool, []ValidationError) {
+	errors, ok := generatedAccountRules.Compile(nil, "")
+	if !ok {
+		return false, errors
+	}
+	obj := reflect.ValueOf(g).Elem()
+
+	if len(g.Name) == 0 {
+		errors = append(errors, ValidationError{Key: "Name", Message: "is required", Code: "required", Value: g.Name})
+	}
+	if len(g.Name) < 3 && !g.broken {
+		errors = append(errors, ValidationError{
+			Key: "Name", Message: "must be at least 3 characters", Code: "min_length", Params: []string{"3"}, Value: g.Name,
+		})
+	}
+	if err, field := generatedAccountRules.Validate(0, g.Confirm, obj, ""); err != nil {
+		if field {
+			err.Key = "Confirm"
+		}
+		errors = append(errors, *err)
+	}
+	if err, field := generatedAccountRules.Validate(1, g.Nick, obj, ""); err != nil {
+		if field {
+			err.Key = "Nick"
+		}
+		errors = append(errors, *err)
+	}
+	return len(errors) == 0, errors
+}
+
+// generatedAccountRules are the rules of generatedAccount run by their validations
+var generatedAccountRules = NewGeneratedRules("validate.generatedAccount", //nolint:gochecknoglobals // Rules of the generated test type
+	GeneratedRule{Field: "Confirm", Index: 1, Rule: "compare=Name", Kind: reflect.String},
+	GeneratedRule{Field: "Nick", Index: 2, Rule: "required", Kind: reflect.String},
+)
+
+// TestGeneratedRules tests running rules through their validations
+func TestGeneratedRules(t *testing.T) {
+	nick := ""
+	account := &generatedAccount{Name: "Al", Confirm: "Bob", Nick: &nick}
+	ok, errs := account.Validate()
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "must be at least 3 characters", Code: "min_length", Params: []string{"3"}, Value: "Al"},
+		{
+			Key: "Confirm", Message: "is not the same as the compare field Name", Code: "compare",
+			Params: []string{"Name"}, Value: "Bob",
+		},
+	}, errs)
+	require.NoError(t, CrossCheck(account))
+
+	// Presence validations receive the value as declared
+	account.Nick = nil
+	_, errs = account.Validate()
+	assert.Equal(t, ValidationError{Key: "Nick", Message: "is required", Code: "required", Value: (*string)(nil)},
+		errs[len(errs)-1])
+
+	// Errors about other fields are keyed with the prefix
+	rules := NewGeneratedRules("validate.generatedAccount",
+		GeneratedRule{Field: "Confirm", Index: 1, Rule: "compare=Nick", Kind: reflect.String},
+	)
+	_, ok = rules.Compile(nil, "")
+	require.True(t, ok)
+	err, field := rules.Validate(0, "Al", reflect.ValueOf(account).Elem(), "Account.")
+	require.NotNil(t, err)
+	assert.False(t, field)
+	assert.Equal(t, ValidationError{
+		Key:     "Account.Nick",
+		Message: "is not of type string and StringEqualsValidation only accepts strings",
+		Code:    "type",
+		Params:  []string{"string"},
+		Value:   "Al",
+	}, *err)
+
+	// Other validations are skipped for nil values
+	err, _ = rules.Validate(0, (*string)(nil), reflect.ValueOf(account).Elem(), "")
+	assert.Nil(t, err)
+}
+
+// TestGeneratedRulesCompile tests the errors of rules that cannot be built
+func TestGeneratedRulesCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     GeneratedRule
+		expected ValidationError
+	}{
+		{
+			name:     "unknown validation",
+			rule:     GeneratedRule{Field: "Name", Rule: "unknown_validation", Kind: reflect.String},
+			expected: ValidationError{Key: "Account.Name", Message: `has an invalid validation "unknown_validation": unknown validation named: unknown_validation`},
+		},
+		{
+			name:     "builder error",
+			rule:     GeneratedRule{Field: "Name", Rule: "min=3", Kind: reflect.String},
+			expected: ValidationError{Key: "Account.Name", Message: `has an invalid validation "min=3": invalid_validation field is not of numeric type and min validation only accepts numeric types`},
+		},
+		{
+			name:     "syntax error",
+			rule:     GeneratedRule{Field: "Name", Rule: "format='regexp", Kind: reflect.String},
+			expected: ValidationError{Key: "Account.Name", Message: `has an invalid validation "format='regexp": syntax error at column 8: unterminated quote`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs, ok := NewGeneratedRules("validate.Account", test.rule).Compile(nil, "Account.")
+			assert.False(t, ok)
+			assert.Equal(t, []ValidationError{test.expected}, withoutDetails(errs))
+		})
+	}
+}
+
+// TestGeneratedHelpers tests the helpers of the generated code
+func TestGeneratedHelpers(t *testing.T) {
+	keys, names := GeneratedMapKeys(map[int]bool{10: true, 2: true, 1: false})
+	assert.Equal(t, []int{1, 10, 2}, keys)
+	assert.Equal(t, []string{"1", "10", "2"}, names)
+
+	assert.True(t, GeneratedIsEmpty([2]int{}))
+	assert.False(t, GeneratedIsEmpty([2]int{0, 1}))
+	assert.True(t, GeneratedIsEmpty[interface{}](nil))
+	assert.False(t, GeneratedIsEmpty[interface{}](0))
+
+	errs := GeneratedStructErrors(nil, []ValidationError{{Message: "is invalid"}, {Key: "End", Message: "is late"}}, "Stay.")
+	assert.Equal(t, []ValidationError{{Key: "Stay", Message: "is invalid"}, {Key: "Stay.End", Message: "is late"}}, errs)
+
+	type address struct {
+		City string `validation:"required"`
+	}
+	errs = GeneratedStruct(nil, &address{}, "Address.", nil)
+	assert.Equal(t, []ValidationError{{Key: "Address.City", Message: "is required"}}, withoutDetails(errs))
+}
+
+// generatedFolder is a struct with a hand-written "generated" Validate method, holding a struct
+// without generated code (generatedOwner) that leads back to it
+type generatedFolder struct {
+	Name  string          `validation:"required"`
+	Owner *generatedOwner `validation:"required"`
+}
+
+// generatedOwner is a struct without generated code, pointing back to its folder
+type generatedOwner struct {
+	Name   string `validation:"required"`
+	Folder *generatedFolder
+}
+
+// Validate validates the folder as the generated code would
+func (g *generatedFolder) Validate() (bool, []ValidationError) {
+	errors := g.validateGenerated(nil, "", nil)
+	return len(errors) == 0, errors
+}
+
+// validateGenerated validates the folder at the path of the prefix, as the generated code would
+func (g *generatedFolder) validateGenerated(errors []ValidationError, prefix string, visited *GeneratedVisit,
+) []ValidationError {
+	visit, ok := visited.Visit(g)
+	if !ok {
+		return errors
+	}
+
+	if len(g.Name) == 0 {
+		errors = append(errors, ValidationError{Key: prefix + "Name", Message: "is required", Code: "required", Value: g.Name})
+	}
+	if g.Owner == nil {
+		errors = append(errors, ValidationError{Key: prefix + "Owner", Message: "is required", Code: "required", Value: g.Owner})
+	} else {
+		errors = GeneratedStruct(errors, g.Owner, prefix+"Owner.", &visit)
+	}
+	return errors
+}
+
+// TestGeneratedCycles tests the generated code stops at cycles of pointers as IsValid does
+func TestGeneratedCycles(t *testing.T) {
+	folder := &generatedFolder{}
+	folder.Owner = &generatedOwner{Folder: folder}
+
+	// Visiting the folder again through its owner finds the cycle
+	visit, ok := (*GeneratedVisit)(nil).Visit(folder)
+	require.True(t, ok)
+	owner, ok := visit.Visit(folder.Owner)
+	require.True(t, ok)
+	_, ok = owner.Visit(folder)
+	assert.False(t, ok)
+
+	// Values that are not addressable are never part of a cycle
+	_, ok = visit.Visit(generatedOwner{})
+	assert.True(t, ok)
+
+	// The struct without generated code does not validate the folder again
+	ok, errs := folder.Validate()
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "is required", Code: "required", Value: ""},
+		{Key: "Owner.Name", Message: "is required", Code: "required", Value: ""},
+	}, errs)
+	require.NoError(t, CrossCheck(folder))
+}
+
+// TestCrossCheck tests comparing generated validations with IsValid
+func TestCrossCheck(t *testing.T) {
+	require.NoError(t, CrossCheck(&generatedAccount{Name: "Alice", Confirm: "Alice"}))
+	require.NoError(t, CrossCheckRandom(&generatedAccount{}, rand.New(rand.NewSource(1)), 100))
+
+	err := CrossCheck(&generatedAccount{Name: "Al", broken: true})
+	require.ErrorIs(t, err, ErrGeneratedMismatch)
+}
@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ValueRule validates values of type T, such as a ValueRule[string] for an email address or a
+// ValueRule[[]Address] for a list of addresses. The rules use the tag syntax (see Rule) and the
+// registered validations, and are built for T when the ValueRule is created, so rules that do not
+// apply to T (e.g. min_length for an int) are reported once instead of on every call:
+//
+//	email := validate.MustRule[string]("Email", "required format=email")
+//	ok, errs := email.Validate(input)
+//
+// Errors are keyed by the name of the rule, and elements and struct fields by their path from it
+// (e.g. "Addresses[1].City"). Rules comparing to other fields (e.g. compare or required_if) do not
+// apply to single values, and fail with an unknown_field error as there is no other field.
+type ValueRule[T any] struct {
+	// m is the map holding the validations and the plans of nested structs
+	m *Map
+
+	// name is the key of the errors
+	name string
+
+	// rules are the validations built from the tag
+	rules *ruleSet
+}
+
+// NewRule creates a ValueRule for values of type T from the rules using DefaultMap.
+// A *CompileError is returned when the rules cannot be built for T.
+func NewRule[T any](name, rules string) (*ValueRule[T], error) {
+	return NewMapRule[T](&DefaultMap, name, rules)
+}
+
+// NewMapRule creates a ValueRule for values of type T from the rules using the given map.
+// A *CompileError is returned when the rules cannot be built for T.
+func NewMapRule[T any](m *Map, name, rules string) (*ValueRule[T], error) {
+	valueType := reflect.TypeOf((*T)(nil)).Elem()
+	field := reflect.StructField{Name: name, Type: valueType}
+
+	valueRule := &ValueRule[T]{m: m, name: name, rules: newRuleSet(valueType)}
+	if err := m.buildFieldRules(valueRule.rules, valueType, field, 0, rules, nil, nil); err != nil {
+		return nil, err
+	}
+	return valueRule, nil
+}
+
+// MustRule is like NewRule but panics when the rules cannot be built, for rules declared as globals
+func MustRule[T any](name, rules string) *ValueRule[T] {
+	valueRule, err := NewRule[T](name, rules)
+	if err != nil {
+		panic(err)
+	}
+	return valueRule
+}
+
+// Validate determines if the value is valid
+func (r *ValueRule[T]) Validate(value T) (bool, []ValidationError) {
+	root := getPath("")
+	errors := r.m.validateValue(nil, r.rules, reflect.ValueOf(&value).Elem(), reflect.Value{}, root,
+		root.push(pathSegment{name: r.name}), runMode{})
+	putPath(root)
+	return len(errors) == 0, errors
+}
+
+// Validate determines if a struct (or a pointer to a struct) is valid using DefaultMap, like
+// IsValid but without converting the value to an interface. As Go cannot restrict a type parameter
+// to structs, any other type is reported as ErrNotStruct like IsValid.
+func Validate[T any](value T) (bool, []ValidationError) {
+	return DefaultMap.validateObject(reflect.ValueOf(&value).Elem(), runMode{})
+}
+
+// ValidateSlice determines if each struct (or pointer to a struct) of the slice is valid using
+// DefaultMap. The errors are keyed by the index of the struct (e.g. "[2].Email").
+func ValidateSlice[T any](values []T) (bool, []ValidationError) {
+	var errors []ValidationError
+	for i := range values {
+		_, elementErrors := DefaultMap.validateObject(reflect.ValueOf(&values[i]), runMode{})
+		for _, err := range elementErrors {
+			if len(err.Key) == 0 {
+				err.Key = "[" + strconv.Itoa(i) + "]"
+			} else {
+				err.Key = "[" + strconv.Itoa(i) + "]." + err.Key
+			}
+			errors = append(errors, err)
+		}
+	}
+	return len(errors) == 0, errors
+}
+
+// Set is a set of values of type T, such as the allowed values of an enum
+type Set[T comparable] map[T]struct{}
+
+// NewSet creates a set of the values
+func NewSet[T comparable](values ...T) Set[T] {
+	set := make(Set[T], len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+// Contains determines if the value is in the set
+func (s Set[T]) Contains(value T) bool {
+	_, ok := s[value]
+	return ok
+}
+
+// setValidation type used for values that must be in a set
+type setValidation[T comparable] struct {
+	// Validation is the validation interface
+	Validation
+
+	// allowed are the allowed values
+	allowed Set[T]
+
+	// params are the allowed values printed in their order, the parameters of the errors
+	params []string
+}
+
+// Validate is for the setValidation type and will test the value is in the set
+func (s *setValidation[T]) Validate(value interface{}, _ reflect.Value) *ValidationError {
+	if typed, ok := value.(T); ok && s.allowed.Contains(typed) {
+		return nil
+	}
+
+	return &ValidationError{
+		Key:     s.FieldName(),
+		Message: "is not an allowed value",
+		Code:    "one_of",
+		Params:  append([]string(nil), s.params...),
+		Err:     ErrEnumValueNotAllowed,
+	}
+}
+
+// OneOf creates a ValueRule for values that must be one of the allowed values, the typed
+// counterpart of IsValidEnum
+func OneOf[T comparable](name string, allowed ...T) *ValueRule[T] {
+	params := make([]string, len(allowed))
+	for i, value := range allowed {
+		params[i] = fmt.Sprint(value)
+	}
+	validation := &setValidation[T]{allowed: NewSet(allowed...), params: params}
+	validation.SetFieldName(name)
+
+	return &ValueRule[T]{
+		m:     &DefaultMap,
+		name:  name,
+		rules: &ruleSet{validations: []Interface{validation}},
+	}
+}
@@ -0,0 +1,177 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genericAddress is a struct validated through the generic functions
+type genericAddress struct {
+	City       string `validation:"min_length=2"`
+	PostalCode string `validation:"format=regexp:^[0-9]{5}$"`
+}
+
+// TestNewRule tests typed rules built from the registered validations
+func TestNewRule(t *testing.T) {
+	t.Run("string rule", func(t *testing.T) {
+		email := MustRule[string]("Email", "required format=email")
+
+		ok, errs := email.Validate("john@domain.com")
+		assert.True(t, ok)
+		assert.Empty(t, errs)
+
+		ok, errs = email.Validate("")
+		assert.False(t, ok)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "Email is required", errs[0].Error())
+	})
+
+	t.Run("slice rule with dive", func(t *testing.T) {
+		emails := MustRule[[]string]("Emails", "dive format=email")
+
+		ok, errs := emails.Validate([]string{"john@domain.com", "invalid"})
+		assert.False(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Emails[1]", errs[0].Key)
+	})
+
+	t.Run("struct rule", func(t *testing.T) {
+		addresses := MustRule[[]*genericAddress]("Addresses", "dive required")
+
+		ok, errs := addresses.Validate([]*genericAddress{{City: "Denver", PostalCode: "80202"}, {City: "D"}, nil})
+		assert.False(t, ok)
+		keys := make([]string, 0, len(errs))
+		for _, err := range errs {
+			keys = append(keys, err.Key)
+		}
+		assert.ElementsMatch(t, []string{"Addresses[1].City", "Addresses[1].PostalCode", "Addresses[2]"}, keys)
+	})
+
+	t.Run("rule without a name", func(t *testing.T) {
+		address := MustRule[genericAddress]("", "")
+
+		_, errs := address.Validate(genericAddress{City: "Denver"})
+		require.Len(t, errs, 1)
+		assert.Equal(t, "PostalCode", errs[0].Key)
+	})
+
+	t.Run("rule for another map", func(t *testing.T) {
+		m := &Map{}
+		m.AddValidation("min", minValueValidation)
+
+		age, err := NewMapRule[uint8](m, "Age", "min=18")
+		require.NoError(t, err)
+
+		ok, _ := age.Validate(18)
+		assert.True(t, ok)
+		ok, _ = age.Validate(17)
+		assert.False(t, ok)
+
+		_, err = NewMapRule[uint8](m, "Age", "required")
+		require.ErrorIs(t, err, ErrUnknownValidation)
+	})
+
+	t.Run("invalid rules for the type", func(t *testing.T) {
+		_, err := NewRule[[]int]("Counts", "gt_field=Limit")
+		require.ErrorIs(t, err, ErrNotOrdered)
+
+		var compileErr *CompileError
+		require.ErrorAs(t, err, &compileErr)
+		assert.Equal(t, "[]int", compileErr.Struct)
+		assert.Equal(t, "Counts", compileErr.Field)
+
+		_, err = NewRule[string]("Name", "dive")
+		require.ErrorIs(t, err, ErrInvalidDive)
+
+		assert.Panics(t, func() {
+			MustRule[string]("Name", "unknown")
+		})
+	})
+
+	t.Run("rules comparing to other fields", func(t *testing.T) {
+		for _, rules := range []string{
+			"compare=Other",
+			"eq_field=Other",
+			"ne_field=Other",
+			"gt_field=Other",
+			"gte_field=Other",
+			"lt_field=Other",
+			"lte_field=Other",
+			"required_if=Other,a",
+			"required_unless=Other,a",
+			"required_with=Other",
+			"excluded_with=Other",
+		} {
+			ok, errs := MustRule[string]("Name", rules).Validate("a")
+			assert.False(t, ok, rules)
+			require.Len(t, errs, 1, rules)
+			assert.Equal(t, "Name", errs[0].Key, rules)
+			assert.Equal(t, "unknown_field", errs[0].Code, rules)
+			assert.Equal(t, []string{"Other"}, errs[0].Params, rules)
+		}
+	})
+}
+
+// TestValidate tests validating structs through the generic functions
+func TestValidate(t *testing.T) {
+	ok, errs := Validate(genericAddress{City: "Denver", PostalCode: "80202"})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = Validate(&genericAddress{City: "D", PostalCode: "80202"})
+	assert.False(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "City", errs[0].Key)
+
+	ok, errs = Validate[*genericAddress](nil)
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{{Message: ErrNotStruct.Error(), Err: ErrNotStruct}}, errs)
+
+	ok, errs = Validate("not a struct")
+	assert.False(t, ok)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, ErrNotStruct.Error())
+}
+
+// TestValidateSlice tests validating each struct of a slice
+func TestValidateSlice(t *testing.T) {
+	ok, errs := ValidateSlice([]genericAddress{{City: "Denver", PostalCode: "80202"}})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = ValidateSlice([]*genericAddress{{City: "Denver", PostalCode: "80202"}, {City: "D", PostalCode: "80202"}, nil})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "[1].City", Message: "must be at least 2 characters"},
+		{Key: "[2]", Message: ErrNotStruct.Error()},
+	}, withoutDetails(errs))
+}
+
+// TestSet tests sets and the OneOf rule
+func TestSet(t *testing.T) {
+	type Status string
+
+	set := NewSet[Status]("pending", "shipped")
+	assert.True(t, set.Contains("pending"))
+	assert.False(t, set.Contains("cancelled"))
+	assert.Len(t, NewSet(1, 2, 2, 3), 3)
+
+	status := OneOf[Status]("Status", "pending", "shipped")
+	ok, errs := status.Validate("shipped")
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = status.Validate("cancelled")
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{{
+		Key: "Status", Message: "is not an allowed value", Code: "one_of", Params: []string{"pending", "shipped"},
+		Value: Status("cancelled"), Err: ErrEnumValueNotAllowed,
+	}}, errs)
+
+	// The set validation can only be satisfied by values of its type
+	validation := &setValidation[int]{allowed: NewSet(1)}
+	assert.NotNil(t, validation.Validate("1", reflect.Value{}))
+}
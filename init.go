@@ -11,5 +11,19 @@ func InitValidations() {
 	initOnce.Do(func() {
 		RegisterStringValidations()
 		RegisterNumericValidations()
+		RegisterRequiredValidations()
+		RegisterComparisonValidations()
 	})
 }
+
+// NewMap creates a validation map with the built-in validations registered, independent of
+// DefaultMap and of other maps, e.g. for tenants or test suites with their own validations.
+// Use DefaultMap.Clone() to start from the validations registered with DefaultMap instead.
+func NewMap() *Map {
+	m := &Map{}
+	registerStringValidations(m)
+	registerNumericValidations(m)
+	registerRequiredValidations(m)
+	registerComparisonValidations(m)
+	return m
+}
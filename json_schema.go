@@ -0,0 +1,366 @@
+package validate
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// JSONSchemaDraft is the $schema of the documents generated by JSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaContributor is implemented by validations that can be described in JSON Schema, such
+// as min_length (minLength). JSONSchema adds the keywords of the validation to the schema of the
+// value it validates. Custom validations implement it to contribute their own keywords.
+type JSONSchemaContributor interface {
+	// JSONSchema adds the keywords of the validation to the schema
+	JSONSchema(schema map[string]interface{})
+}
+
+// JSONSchema generates a JSON Schema (draft 2020-12) document for the struct type from its
+// validation tags (and rules declared in code), using the json tags for the property names.
+// Built-in validations add minimum, maximum, minLength, maxLength, pattern and format, required
+// fields are listed in required, and other validations add keywords by implementing
+// JSONSchemaContributor. Named structs are defined once in $defs and referenced with $ref.
+//
+// The keywords do not always have the meaning of the rules: min_length and max_length count bytes
+// while minLength and maxLength count characters, so they only agree for ASCII strings, and
+// regular expressions using RE2 syntax unknown to ECMA-262 (e.g. `(?i)` or `\z`) add no pattern.
+func (m *Map) JSONSchema(objectType reflect.Type) (map[string]interface{}, error) {
+	if objectType == nil || indirectType(objectType).Kind() != reflect.Struct {
+		return nil, &CompileError{Err: ErrNotStruct}
+	}
+	objectType = indirectType(objectType)
+
+	// The root is referenced as the document itself
+	generator := newJSONSchemaGenerator(m, "#/$defs/")
+	generator.names[objectType] = ""
+
+	schema, err := generator.structSchema(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	schema["$schema"] = JSONSchemaDraft
+	if len(generator.definitions) > 0 {
+		schema["$defs"] = generator.definitions
+	}
+	return schema, nil
+}
+
+// jsonSchemaGenerator generates the schemas of types, defining each named struct once
+type jsonSchemaGenerator struct {
+	// m holds the validations of the types
+	m *Map
+
+	// refPrefix is the prefix of the references to definitions (e.g. "#/$defs/")
+	refPrefix string
+
+	// definitions are the schemas of the named structs by name
+	definitions map[string]interface{}
+
+	// names are the names of the defined structs, "" for the root document
+	names map[reflect.Type]string
+
+	// extensions is set to add the OpenAPI extensions of the rules (see AddOpenAPIExtension)
+	extensions bool
+}
+
+// newJSONSchemaGenerator creates a generator referencing definitions with the prefix
+func newJSONSchemaGenerator(m *Map, refPrefix string) *jsonSchemaGenerator {
+	return &jsonSchemaGenerator{
+		m:           m,
+		refPrefix:   refPrefix,
+		definitions: map[string]interface{}{},
+		names:       map[reflect.Type]string{},
+	}
+}
+
+// valueSchema generates the schema of a value of the type validated by the rules (which may be nil)
+func (g *jsonSchemaGenerator) valueSchema(valueType reflect.Type, rules *ruleSet) (map[string]interface{}, error) {
+	valueType = indirectType(valueType)
+	schema, err := g.typeSchema(valueType)
+	if err != nil || rules == nil {
+		return schema, err
+	}
+
+	for _, validation := range rules.validations {
+		if contributor, ok := validation.(JSONSchemaContributor); ok {
+			contributor.JSONSchema(schema)
+		}
+	}
+	if g.extensions {
+		for _, rule := range rules.rules {
+			if err = g.m.addOpenAPIExtensions(schema, rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Rules of the elements (dive) and keys of collections
+	if rules.elements != nil {
+		elements, err := g.valueSchema(valueType.Elem(), rules.elements)
+		if err != nil {
+			return nil, err
+		}
+		if valueType.Kind() == reflect.Map {
+			schema["additionalProperties"] = elements
+		} else {
+			schema["items"] = elements
+		}
+	}
+	if rules.keys != nil {
+		keys, err := g.valueSchema(valueType.Key(), rules.keys)
+		if err != nil {
+			return nil, err
+		}
+		schema["propertyNames"] = keys
+	}
+
+	return schema, nil
+}
+
+// typeSchema generates the schema of a type without its validations
+func (g *jsonSchemaGenerator) typeSchema(valueType reflect.Type) (map[string]interface{}, error) {
+	kind := valueType.Kind()
+	switch {
+	case valueType == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	case kind == reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case isUintKind(kind):
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case isFloatKind(kind):
+		return map[string]interface{}{"type": "number"}, nil
+	case isNumericKind(kind):
+		return map[string]interface{}{"type": "integer"}, nil
+	case kind == reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case kind == reflect.Slice && valueType.Elem().Kind() == reflect.Uint8:
+		// Byte slices are encoded as base64 strings
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+	case kind == reflect.Slice || kind == reflect.Array:
+		items, err := g.valueSchema(valueType.Elem(), nil)
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]interface{}{"type": "array", "items": items}
+		if kind == reflect.Array {
+			schema["minItems"], schema["maxItems"] = valueType.Len(), valueType.Len()
+		}
+		return schema, nil
+	case kind == reflect.Map:
+		values, err := g.valueSchema(valueType.Elem(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case kind == reflect.Struct && len(valueType.Name()) > 0:
+		return g.structReference(valueType)
+	case kind == reflect.Struct:
+		return g.structSchema(valueType)
+	default:
+		// Interfaces (and types JSON cannot encode) can hold anything
+		return map[string]interface{}{}, nil
+	}
+}
+
+// structReference references the definition of a named struct, defining it on first use
+func (g *jsonSchemaGenerator) structReference(structType reflect.Type) (map[string]interface{}, error) {
+	name, ok := g.names[structType]
+	if !ok {
+		// Structs of other packages with the same name are qualified by their package
+		name = structType.Name()
+		if _, taken := g.definitions[name]; taken {
+			name = path.Base(structType.PkgPath()) + "." + name
+		}
+		g.names[structType] = name
+		g.definitions[name] = nil
+
+		schema, err := g.structSchema(structType)
+		if err != nil {
+			return nil, err
+		}
+		g.definitions[name] = schema
+	}
+
+	if len(name) == 0 {
+		return map[string]interface{}{"$ref": "#"}, nil
+	}
+	return map[string]interface{}{"$ref": g.refPrefix + name}, nil
+}
+
+// structSchema generates the schema of the properties of a struct
+func (g *jsonSchemaGenerator) structSchema(structType reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+	if err := g.addProperties(structType, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// addProperties adds the exported fields of the struct (and of its embedded structs) to the properties
+func (g *jsonSchemaGenerator) addProperties(structType reflect.Type, properties map[string]interface{},
+	required *[]string,
+) error {
+	plan := g.m.plan(structType, "")
+	if plan.err != nil {
+		return plan.err
+	}
+	fieldRules := make(map[int]*ruleSet, len(plan.fields))
+	for i := range plan.fields {
+		fieldRules[plan.fields[i].index] = &plan.fields[i].rules
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		// Fields of embedded structs without a json name are promoted, as encoding/json does
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && isStructType(field.Type) && len(tagName) == 0 {
+			if err := g.addProperties(indirectType(field.Type), properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rules := fieldRules[i]
+		property, err := g.valueSchema(field.Type, rules)
+		if err != nil {
+			return err
+		}
+		properties[name] = property
+
+		if rules != nil && isRequired(rules) {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// jsonFieldName gets the name of the field in JSON, and whether encoding/json skips it
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", true
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name, _, _ := strings.Cut(tag, ","); len(name) > 0 {
+		return name, false
+	}
+	return field.Name, !field.IsExported() && !isStructType(field.Type)
+}
+
+// isRequired determines if the rules of a value include required
+func isRequired(rules *ruleSet) bool {
+	for _, validation := range rules.validations {
+		if _, ok := validation.(*requiredValidation); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONSchema adds the minLength keyword
+func (m *minLengthStringValidation) JSONSchema(schema map[string]interface{}) {
+	schema["minLength"] = m.length
+}
+
+// JSONSchema adds the maxLength keyword
+func (m *maxLengthStringValidation) JSONSchema(schema map[string]interface{}) {
+	schema["maxLength"] = m.length
+}
+
+// JSONSchema adds the format keyword for emails, and the pattern keyword for regular expressions
+// that have the same meaning in ECMA-262, the syntax of JSON Schema patterns
+func (f *formatStringValidation) JSONSchema(schema map[string]interface{}) {
+	if f.patternName == "email" {
+		schema["format"] = "email"
+		return
+	}
+	if pattern := f.pattern.String(); isECMAPattern(pattern) {
+		schema["pattern"] = pattern
+	}
+}
+
+// isECMAPattern determines if the RE2 regular expression has no syntax unknown to (or read
+// differently by) ECMA-262: flags and named groups (e.g. `(?i)`), the `\A`, `\z`, `\Q...\E` and
+// `\C` escapes, Unicode classes (`\pL`, which need the u flag), `\x{...}` and POSIX classes
+func isECMAPattern(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			if strings.IndexByte("AzQECpP", pattern[i]) >= 0 ||
+				(pattern[i] == 'x' && i+1 < len(pattern) && pattern[i+1] == '{') {
+				return false
+			}
+		case strings.HasPrefix(pattern[i:], "(?") && !strings.HasPrefix(pattern[i:], "(?:"):
+			return false
+		case strings.HasPrefix(pattern[i:], "[:"):
+			return false
+		}
+	}
+	return true
+}
+
+// JSONSchema adds the enum keyword with the allowed values, in the order of the rule
+func (o *oneOfStringValidation) JSONSchema(schema map[string]interface{}) {
+	schema["enum"] = o.allowed
+}
+
+// JSONSchema adds the minimum (min) or maximum (max) keyword
+func (i *intValueValidation) JSONSchema(schema map[string]interface{}) {
+	addBoundKeyword(schema, i.less, i.value)
+}
+
+// JSONSchema adds the minimum (min) or maximum (max) keyword
+func (u *uintValueValidation) JSONSchema(schema map[string]interface{}) {
+	addBoundKeyword(schema, u.less, u.value)
+}
+
+// JSONSchema adds the minimum (min) or maximum (max) keyword
+func (f *floatValueValidation) JSONSchema(schema map[string]interface{}) {
+	addBoundKeyword(schema, f.less, f.value)
+}
+
+// addBoundKeyword adds the minimum keyword for lower bounds, or the maximum keyword
+func addBoundKeyword(schema map[string]interface{}, lower bool, bound interface{}) {
+	if lower {
+		schema["minimum"] = bound
+	} else {
+		schema["maximum"] = bound
+	}
+}
+
+// JSONSchema adds the enum keyword with the values of the set
+func (s *setValidation[T]) JSONSchema(schema map[string]interface{}) {
+	values := make([]interface{}, 0, len(s.allowed))
+	for value := range s.allowed {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return fmt.Sprint(values[i]) < fmt.Sprint(values[j])
+	})
+	schema["enum"] = values
+}
+
+// JSONSchema generates a JSON Schema document for the struct type using DefaultMap, see Map.JSONSchema
+func JSONSchema(objectType reflect.Type) (map[string]interface{}, error) {
+	return DefaultMap.JSONSchema(objectType)
+}
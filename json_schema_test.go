@@ -0,0 +1,171 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaAudit is embedded in schemaCustomer, its fields are promoted
+type schemaAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// schemaAddress is a nested struct defined once in $defs
+type schemaAddress struct {
+	City       string `json:"city" validation:"required min_length=2 max_length=50"`
+	PostalCode string `json:"postal_code" validation:"format=regexp:^[0-9]{5}$"`
+}
+
+// schemaCustomer is the struct the schema is generated for
+type schemaCustomer struct {
+	schemaAudit
+	Email     string           `json:"email" validation:"required format=email"`
+	Age       uint8            `json:"age" validation:"min=18 max=120"`
+	Balance   float64          `json:"balance,omitempty" validation:"min=0"`
+	Color     string           `json:"color" validation:"color"`
+	Home      schemaAddress    `json:"home" validation:"required"`
+	Shipping  []*schemaAddress `json:"shipping"`
+	Emails    []string         `json:"emails" validation:"dive format=email"`
+	Limits    map[string]int   `json:"limits" validation:"dive keys min_length=2 endkeys min=1"`
+	Referrer  *schemaCustomer  `json:"referrer"`
+	Metadata  interface{}      `json:"metadata"`
+	Avatar    []byte           `json:"avatar"`
+	Point     [2]int           `json:"point"`
+	Internal  string           `json:"-"`
+	NoJSONTag bool
+	Labels    map[string]string `json:"labels"`
+	secret    string
+}
+
+// colorValidation is a custom validation contributing its own keywords
+type colorValidation struct {
+	Validation
+}
+
+// Validate accepts any value
+func (c *colorValidation) Validate(_ interface{}, _ reflect.Value) *ValidationError {
+	return nil
+}
+
+// JSONSchema adds the enum of the colors
+func (c *colorValidation) JSONSchema(schema map[string]interface{}) {
+	schema["enum"] = []string{"red", "green", "blue"}
+}
+
+// TestMapJSONSchema tests generating a JSON Schema from the validation tags
+func TestMapJSONSchema(t *testing.T) {
+	m := &Map{}
+	m.AddValidation("min_length", minLengthValidation)
+	m.AddValidation("max_length", maxLengthValidation)
+	m.AddValidation("format", formatValidation)
+	m.AddValidation("min", minValueValidation)
+	m.AddValidation("max", maxValueValidation)
+	m.AddRuleValidation("required", requiredRuleValidation)
+	m.AddValidation("color", func(string, reflect.Kind) (Interface, error) {
+		return &colorValidation{}, nil
+	})
+
+	schema, err := m.JSONSchema(reflect.TypeOf(&schemaCustomer{}))
+	require.NoError(t, err)
+
+	document, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["email", "home"],
+		"properties": {
+			"created_at": {"type": "string", "format": "date-time"},
+			"email": {"type": "string", "format": "email"},
+			"age": {"type": "integer", "minimum": 18, "maximum": 120},
+			"balance": {"type": "number", "minimum": 0},
+			"color": {"type": "string", "enum": ["red", "green", "blue"]},
+			"home": {"$ref": "#/$defs/schemaAddress"},
+			"shipping": {"type": "array", "items": {"$ref": "#/$defs/schemaAddress"}},
+			"emails": {"type": "array", "items": {"type": "string", "format": "email"}},
+			"limits": {
+				"type": "object",
+				"propertyNames": {"type": "string", "minLength": 2},
+				"additionalProperties": {"type": "integer", "minimum": 1}
+			},
+			"referrer": {"$ref": "#"},
+			"metadata": {},
+			"avatar": {"type": "string", "contentEncoding": "base64"},
+			"point": {"type": "array", "items": {"type": "integer"}, "minItems": 2, "maxItems": 2},
+			"NoJSONTag": {"type": "boolean"},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+		},
+		"$defs": {
+			"schemaAddress": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {
+					"city": {"type": "string", "minLength": 2, "maxLength": 50},
+					"postal_code": {"type": "string", "pattern": "^[0-9]{5}$"}
+				}
+			}
+		}
+	}`, string(document))
+}
+
+// TestMapJSONSchemaErrors tests the errors of generating a JSON Schema
+func TestMapJSONSchemaErrors(t *testing.T) {
+	_, err := JSONSchema(reflect.TypeOf(1))
+	require.ErrorIs(t, err, ErrNotStruct)
+
+	_, err = JSONSchema(nil)
+	require.ErrorIs(t, err, ErrNotStruct)
+
+	type Invalid struct {
+		Name string `validation:"unknown_validation"`
+	}
+	type Parent struct {
+		Children []Invalid
+	}
+	_, err = JSONSchema(reflect.TypeOf(Parent{}))
+	require.ErrorIs(t, err, ErrUnknownValidation)
+}
+
+// TestJSONSchemaOneOf tests the enum of sets
+func TestJSONSchemaOneOf(t *testing.T) {
+	schema := map[string]interface{}{}
+	OneOf("Size", "m", "s", "l").rules.validations[0].(JSONSchemaContributor).JSONSchema(schema)
+	assert.Equal(t, []interface{}{"l", "m", "s"}, schema["enum"])
+}
+
+// TestJSONSchemaPatterns tests only the regular expressions ECMA-262 reads the same are exported
+func TestJSONSchemaPatterns(t *testing.T) {
+	tests := map[string]bool{
+		`^[0-9]{5}(-[0-9]{4})?$`: true,
+		`^(?:www\.)?[a-z]+$`:     true,
+		`^\d+\\z$`:               true,
+		`^\w+\s\S+$`:             true,
+		`(?i)^[a-z]+$`:           false,
+		`^(?P<year>\d{4})$`:      false,
+		`^[a-z]+\z`:              false,
+		`\A[a-z]+$`:              false,
+		`^\Q1.5\E$`:              false,
+		`^\pL+$`:                 false,
+		`^\p{Greek}+$`:           false,
+		`^\x{1F600}$`:            false,
+		`^[[:alpha:]]+$`:         false,
+	}
+	for pattern, exported := range tests {
+		validation, err := formatValidation("regexp:"+pattern, reflect.String)
+		require.NoError(t, err, pattern)
+
+		schema := map[string]interface{}{}
+		validation.(JSONSchemaContributor).JSONSchema(schema)
+		if exported {
+			assert.Equal(t, map[string]interface{}{"pattern": pattern}, schema, pattern)
+		} else {
+			assert.Empty(t, schema, pattern)
+		}
+	}
+}
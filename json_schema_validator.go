@@ -0,0 +1,984 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of the JSON Schema validator
+const (
+	// maxJSONNumberExponent is the largest exponent of the numbers compared exactly, so a number
+	// such as 1e999999999 cannot exhaust memory
+	maxJSONNumberExponent = 400
+
+	// maxJSONSchemaRefs is the number of references followed for the same value, above which the
+	// references are considered a loop (e.g. {"$ref": "#"})
+	maxJSONSchemaRefs = 32
+)
+
+// jsonSchemaFormats are the asserted formats, other formats are annotations and always valid
+var jsonSchemaFormats = map[string]func(string) bool{ //nolint:gochecknoglobals // Format checks of the JSON Schema validator
+	"email": func(value string) bool {
+		ok, _ := IsValidEmail(value, false)
+		return ok
+	},
+	"ipv4":     IsValidIPv4,
+	"ipv6":     IsValidIPv6,
+	"hostname": IsValidDNSName,
+	"date-time": func(value string) bool {
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	},
+	"date": func(value string) bool {
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	},
+	"time": func(value string) bool {
+		_, err := time.Parse("15:04:05Z07:00", value)
+		return err == nil
+	},
+	"uri": func(value string) bool {
+		u, err := url.Parse(value)
+		return err == nil && u.IsAbs()
+	},
+	"uri-reference": func(value string) bool {
+		_, err := url.Parse(value)
+		return err == nil
+	},
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"regex": func(value string) bool {
+		_, err := regexp.Compile(value)
+		return err == nil
+	},
+}
+
+// jsonSchemaTypes are the values of the type keyword
+var jsonSchemaTypes = map[string]bool{ //nolint:gochecknoglobals // Types of the JSON Schema validator
+	"null": true, SchemaBoolean: true, SchemaObject: true, SchemaArray: true,
+	SchemaNumber: true, SchemaInteger: true, SchemaString: true,
+}
+
+// CompiledJSONSchema validates JSON documents against a JSON Schema, see CompileJSONSchema
+type CompiledJSONSchema struct {
+	root *jsonSchemaNode
+}
+
+// CompileJSONSchema compiles a JSON Schema (draft 2020-12) document. The core and validation
+// vocabularies are supported: $ref to the document itself and to its $id and $anchor resources,
+// allOf, anyOf, oneOf, not, if/then/else, the object and array applicators, and the formats email,
+// ipv4, ipv6, hostname, date-time, date, time, uri, uri-reference, uuid and regex (other formats
+// are annotations). Patterns use the RE2 syntax of the regexp package. A *CompileError is returned
+// for the first invalid keyword (with the location of its schema as its field), and for keywords
+// that are not supported (unevaluatedProperties, unevaluatedItems and $dynamicRef) rather than
+// ignoring them.
+func CompileJSONSchema(document []byte) (*CompiledJSONSchema, error) {
+	raw, err := decodeJSON(document)
+	if err != nil {
+		return nil, &CompileError{Struct: "json schema", Err: err}
+	}
+
+	compiler := &jsonSchemaCompiler{
+		resources: map[string]interface{}{"": raw},
+		nodes:     map[string]*jsonSchemaNode{},
+		anchors:   map[string]*jsonSchemaNode{},
+	}
+	root, err := compiler.compile(raw, &url.URL{}, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	// References are resolved once all the schemas they can target are known
+	for len(compiler.refs) > 0 {
+		ref := compiler.refs[0]
+		compiler.refs = compiler.refs[1:]
+		if ref.node.ref, err = compiler.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+	return &CompiledJSONSchema{root: root}, nil
+}
+
+// Validate determines if the JSON document is valid. The errors are keyed by the JSON Pointer of
+// the values (e.g. "/address/city" or "/tags/2", "" for the document itself). Their codes are those
+// of the rules with the same meaning (e.g. min_length for minLength, min for minimum and one_of for
+// enum), or else the keywords in snake case (e.g. multiple_of), with the values of the keywords as
+// their parameters. A document that is not valid JSON has an invalid_json error with ErrInvalidJSON.
+func (c *CompiledJSONSchema) Validate(document []byte) (bool, []ValidationError) {
+	value, err := decodeJSON(document)
+	if err != nil {
+		return false, []ValidationError{{
+			Message: "is not valid JSON: " + strings.TrimPrefix(err.Error(), ErrInvalidJSON.Error()+": "),
+			Code:    "invalid_json", Err: ErrInvalidJSON,
+		}}
+	}
+	return c.ValidateValue(value)
+}
+
+// ValidateValue determines if a decoded JSON value (e.g. a map[string]interface{}) is valid, see Validate
+func (c *CompiledJSONSchema) ValidateValue(value interface{}) (bool, []ValidationError) {
+	errors := c.root.validate(nil, value, "", 0)
+	return len(errors) == 0, errors
+}
+
+// jsonSchemaNode is a compiled schema
+type jsonSchemaNode struct {
+	// always is the result of the boolean schemas true and false
+	always *bool
+
+	// ref is the schema referenced by $ref
+	ref *jsonSchemaNode
+
+	// Keywords of any value
+	types      []string
+	enum       map[string]bool
+	enumValues []string
+	consts     *string
+
+	// Keywords of numbers
+	multipleOf                *jsonSchemaNumber
+	minimum, exclusiveMinimum *jsonSchemaNumber
+	maximum, exclusiveMaximum *jsonSchemaNumber
+
+	// Keywords of strings, the counts are -1 when absent
+	minLength, maxLength int
+	pattern              *regexp.Regexp
+	format               func(string) bool
+	formatName           string
+
+	// Keywords of objects
+	minProperties, maxProperties int
+	required                     []string
+	dependentRequired            []jsonSchemaDependency
+	properties                   map[string]*jsonSchemaNode
+	patternProperties            []jsonSchemaPattern
+	additionalProperties         *jsonSchemaNode
+	propertyNames                *jsonSchemaNode
+	dependentSchemas             map[string]*jsonSchemaNode
+
+	// Keywords of arrays
+	minItems, maxItems       int
+	minContains, maxContains int
+	uniqueItems              bool
+	prefixItems              []*jsonSchemaNode
+	items                    *jsonSchemaNode
+	contains                 *jsonSchemaNode
+
+	// Combinations and conditions
+	allOf, anyOf, oneOf []*jsonSchemaNode
+	not                 *jsonSchemaNode
+	ifSchema            *jsonSchemaNode
+	thenSchema          *jsonSchemaNode
+	elseSchema          *jsonSchemaNode
+}
+
+// jsonSchemaNumber is a number keyword, with its text for the messages
+type jsonSchemaNumber struct {
+	value *big.Rat
+	text  string
+}
+
+// jsonSchemaDependency is a property required when another property is present
+type jsonSchemaDependency struct {
+	property string
+	required []string
+}
+
+// jsonSchemaPattern is the schema of the properties matching a pattern
+type jsonSchemaPattern struct {
+	pattern *regexp.Regexp
+	schema  *jsonSchemaNode
+}
+
+// jsonSchemaRef is a $ref to resolve once the document is compiled
+type jsonSchemaRef struct {
+	node     *jsonSchemaNode
+	base     *url.URL
+	ref      string
+	location string
+}
+
+// jsonSchemaCompiler compiles the schemas of a document
+type jsonSchemaCompiler struct {
+	// resources are the documents by URI, "" for the root without $id
+	resources map[string]interface{}
+
+	// nodes are the compiled schemas by location (URI#pointer)
+	nodes map[string]*jsonSchemaNode
+
+	// anchors are the schemas by URI#anchor
+	anchors map[string]*jsonSchemaNode
+
+	// refs are the references to resolve
+	refs []jsonSchemaRef
+}
+
+// compile compiles the schema at the location, with the base URI of its references
+func (c *jsonSchemaCompiler) compile(raw interface{}, base *url.URL, location string) (*jsonSchemaNode, error) {
+	node := &jsonSchemaNode{
+		minLength: -1, maxLength: -1, minItems: -1, maxItems: -1, minContains: -1, maxContains: -1,
+		minProperties: -1, maxProperties: -1,
+	}
+	c.nodes[location] = node
+
+	if always, ok := raw.(bool); ok {
+		node.always = &always
+		return node, nil
+	}
+	schema, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, jsonSchemaError(location, "", "a schema must be an object or a boolean")
+	}
+
+	// Resources identified by $id are the base of the references of their schemas
+	if id, ok := schema["$id"]; ok {
+		idURL, err := parseJSONSchemaURI(id)
+		if err != nil {
+			return nil, jsonSchemaError(location, "$id", "%s", err)
+		}
+		base = base.ResolveReference(idURL)
+		base.Fragment, base.RawFragment = "", ""
+		c.resources[base.String()] = raw
+		location = base.String() + "#"
+		c.nodes[location] = node
+	}
+	if anchor, ok := schema["$anchor"]; ok {
+		name, ok := anchor.(string)
+		if !ok || len(name) == 0 {
+			return nil, jsonSchemaError(location, "$anchor", "must be a name")
+		}
+		c.anchors[base.String()+"#"+name] = node
+	}
+
+	for _, keyword := range []string{"unevaluatedProperties", "unevaluatedItems", "$dynamicRef"} {
+		if _, ok := schema[keyword]; ok {
+			return nil, &CompileError{Struct: "json schema", Field: location, Tag: keyword, Err: ErrUnsupportedJSONSchema}
+		}
+	}
+
+	if ref, ok := schema["$ref"]; ok {
+		text, ok := ref.(string)
+		if !ok {
+			return nil, jsonSchemaError(location, "$ref", "must be a string")
+		}
+		c.refs = append(c.refs, jsonSchemaRef{node: node, base: base, ref: text, location: location})
+	}
+
+	if err := c.compileAssertions(node, schema, location); err != nil {
+		return nil, err
+	}
+	return node, c.compileApplicators(node, schema, base, location)
+}
+
+// compileAssertions compiles the keywords of the validation vocabulary
+func (c *jsonSchemaCompiler) compileAssertions(node *jsonSchemaNode, schema map[string]interface{}, location string) error {
+	var err error
+
+	switch types := schema["type"].(type) {
+	case nil:
+	case string:
+		node.types = []string{types}
+	case []interface{}:
+		for _, name := range types {
+			text, _ := name.(string)
+			node.types = append(node.types, text)
+		}
+	default:
+		return jsonSchemaError(location, "type", "must be a type or an array of types")
+	}
+	for _, name := range node.types {
+		if !jsonSchemaTypes[name] {
+			return jsonSchemaError(location, "type", "unknown type %q", name)
+		}
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return jsonSchemaError(location, "enum", "must be an array")
+		}
+		node.enum = make(map[string]bool, len(values))
+		for _, value := range values {
+			node.enum[canonicalJSON(value)] = true
+			node.enumValues = append(node.enumValues, canonicalJSON(value))
+		}
+	}
+	if value, ok := schema["const"]; ok {
+		text := canonicalJSON(value)
+		node.consts = &text
+	}
+
+	numbers := map[string]**jsonSchemaNumber{
+		"multipleOf": &node.multipleOf, "minimum": &node.minimum, "exclusiveMinimum": &node.exclusiveMinimum,
+		"maximum": &node.maximum, "exclusiveMaximum": &node.exclusiveMaximum,
+	}
+	for _, keyword := range sortedKeys(numbers) {
+		if value, ok := schema[keyword]; ok {
+			rat, ok := jsonRat(value)
+			if !ok || (keyword == "multipleOf" && rat.Sign() <= 0) {
+				return jsonSchemaError(location, keyword, "must be a number")
+			}
+			*numbers[keyword] = &jsonSchemaNumber{value: rat, text: fmt.Sprint(value)}
+		}
+	}
+
+	counts := map[string]*int{
+		"minLength": &node.minLength, "maxLength": &node.maxLength, "minItems": &node.minItems,
+		"maxItems": &node.maxItems, "minContains": &node.minContains, "maxContains": &node.maxContains,
+		"minProperties": &node.minProperties, "maxProperties": &node.maxProperties,
+	}
+	for _, keyword := range sortedKeys(counts) {
+		if value, ok := schema[keyword]; ok {
+			if *counts[keyword], ok = jsonCount(value); !ok {
+				return jsonSchemaError(location, keyword, "must be a non-negative integer")
+			}
+		}
+	}
+
+	if pattern, ok := schema["pattern"]; ok {
+		text, ok := pattern.(string)
+		if !ok {
+			return jsonSchemaError(location, "pattern", "must be a regular expression")
+		}
+		if node.pattern, err = regexp.Compile(text); err != nil {
+			return jsonSchemaError(location, "pattern", "%s", err)
+		}
+	}
+	if format, ok := schema["format"]; ok {
+		if node.formatName, ok = format.(string); !ok {
+			return jsonSchemaError(location, "format", "must be a string")
+		}
+		node.format = jsonSchemaFormats[node.formatName]
+	}
+	if unique, ok := schema["uniqueItems"]; ok {
+		if node.uniqueItems, ok = unique.(bool); !ok {
+			return jsonSchemaError(location, "uniqueItems", "must be a boolean")
+		}
+	}
+
+	if required, ok := schema["required"]; ok {
+		if node.required, ok = jsonStrings(required); !ok {
+			return jsonSchemaError(location, "required", "must be an array of property names")
+		}
+	}
+	if dependencies, ok := schema["dependentRequired"]; ok {
+		object, ok := dependencies.(map[string]interface{})
+		if !ok {
+			return jsonSchemaError(location, "dependentRequired", "must be an object")
+		}
+		for _, property := range sortedKeys(object) {
+			required, ok := jsonStrings(object[property])
+			if !ok {
+				return jsonSchemaError(location, "dependentRequired", "must be arrays of property names")
+			}
+			node.dependentRequired = append(node.dependentRequired, jsonSchemaDependency{property: property, required: required})
+		}
+	}
+	return nil
+}
+
+// compileApplicators compiles the keywords applying subschemas
+func (c *jsonSchemaCompiler) compileApplicators(node *jsonSchemaNode, schema map[string]interface{},
+	base *url.URL, location string,
+) error {
+	var err error
+
+	// Schemas of $defs are compiled so references find them by location, and for their errors
+	for _, keyword := range []string{"$defs", "definitions"} {
+		if definitions, ok := schema[keyword].(map[string]interface{}); ok {
+			for _, name := range sortedKeys(definitions) {
+				if _, err = c.compile(definitions[name], base, jsonPointer(location+"/"+keyword, name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	single := map[string]**jsonSchemaNode{
+		"additionalProperties": &node.additionalProperties, "propertyNames": &node.propertyNames,
+		"items": &node.items, "contains": &node.contains, "not": &node.not,
+		"if": &node.ifSchema, "then": &node.thenSchema, "else": &node.elseSchema,
+	}
+	for _, keyword := range sortedKeys(single) {
+		if subschema, ok := schema[keyword]; ok {
+			if *single[keyword], err = c.compile(subschema, base, location+"/"+keyword); err != nil {
+				return err
+			}
+		}
+	}
+
+	lists := map[string]*[]*jsonSchemaNode{
+		"allOf": &node.allOf, "anyOf": &node.anyOf, "oneOf": &node.oneOf, "prefixItems": &node.prefixItems,
+	}
+	for _, keyword := range sortedKeys(lists) {
+		value, ok := schema[keyword]
+		if !ok {
+			continue
+		}
+		subschemas, ok := value.([]interface{})
+		if !ok || (len(subschemas) == 0 && keyword != "prefixItems") {
+			return jsonSchemaError(location, keyword, "must be a non-empty array of schemas")
+		}
+		for i, subschema := range subschemas {
+			compiled, err := c.compile(subschema, base, location+"/"+keyword+"/"+strconv.Itoa(i))
+			if err != nil {
+				return err
+			}
+			*lists[keyword] = append(*lists[keyword], compiled)
+		}
+	}
+
+	objects := map[string]*map[string]*jsonSchemaNode{
+		"properties": &node.properties, "dependentSchemas": &node.dependentSchemas, "patternProperties": nil,
+	}
+	for _, keyword := range sortedKeys(objects) {
+		value, ok := schema[keyword]
+		if !ok {
+			continue
+		}
+		subschemas, ok := value.(map[string]interface{})
+		if !ok {
+			return jsonSchemaError(location, keyword, "must be an object of schemas")
+		}
+		compiled := make(map[string]*jsonSchemaNode, len(subschemas))
+		for _, key := range sortedKeys(subschemas) {
+			if compiled[key], err = c.compile(subschemas[key], base, jsonPointer(location+"/"+keyword, key)); err != nil {
+				return err
+			}
+		}
+		if objects[keyword] != nil {
+			*objects[keyword] = compiled
+			continue
+		}
+
+		// Patterns are matched in order, so errors are reported in a deterministic order
+		for _, key := range sortedKeys(compiled) {
+			pattern, err := regexp.Compile(key)
+			if err != nil {
+				return jsonSchemaError(location, keyword, "%q must be a regular expression", key)
+			}
+			node.patternProperties = append(node.patternProperties, jsonSchemaPattern{pattern: pattern, schema: compiled[key]})
+		}
+	}
+	return nil
+}
+
+// resolve finds the schema referenced by a $ref, compiling it if it is not a known schema
+func (c *jsonSchemaCompiler) resolve(ref jsonSchemaRef) (*jsonSchemaNode, error) {
+	refURL, err := parseJSONSchemaURI(ref.ref)
+	if err != nil {
+		return nil, &CompileError{Struct: "json schema", Field: ref.location, Tag: "$ref", Err: err}
+	}
+	target := ref.base.ResolveReference(refURL)
+	fragment := target.Fragment
+	target.Fragment, target.RawFragment = "", ""
+	uri := target.String()
+
+	// Fragments are JSON Pointers or the names of anchors
+	if len(fragment) > 0 && !strings.HasPrefix(fragment, "/") {
+		if node, ok := c.anchors[uri+"#"+fragment]; ok {
+			return node, nil
+		}
+	} else if node, ok := c.nodes[uri+"#"+fragment]; ok {
+		return node, nil
+	} else if document, ok := c.resources[uri]; ok {
+		if raw, ok := jsonPointerValue(document, fragment); ok {
+			return c.compile(raw, target, uri+"#"+fragment)
+		}
+	}
+
+	return nil, &CompileError{
+		Struct: "json schema", Field: ref.location, Tag: "$ref",
+		Err: fmt.Errorf("%w: %s", ErrJSONSchemaRef, ref.ref),
+	}
+}
+
+// validate validates the value at the JSON Pointer, refs counts the references followed for the value
+func (n *jsonSchemaNode) validate(errors []ValidationError, value interface{}, pointer string, refs int) []ValidationError {
+	if n.always != nil {
+		if !*n.always {
+			return append(errors, jsonSchemaFailure(pointer, "false_schema", "is not allowed"))
+		}
+		return errors
+	}
+
+	if n.ref != nil {
+		if refs >= maxJSONSchemaRefs {
+			return append(errors, jsonSchemaFailure(pointer, "ref_loop", "cannot be validated: the schema references loop"))
+		}
+		errors = n.ref.validate(errors, value, pointer, refs+1)
+	}
+
+	valueType := jsonType(value)
+	if len(n.types) > 0 && !n.hasType(value, valueType) {
+		names := make([]string, 0, len(n.types))
+		for _, name := range n.types {
+			if name == "null" {
+				names = append(names, name)
+			} else {
+				names = append(names, schemaTypeName(name))
+			}
+		}
+		failure := jsonSchemaFailure(pointer, "type", "must be "+strings.Join(names, " or "), n.types...)
+		return append(errors, failure)
+	}
+
+	if n.enum != nil && !n.enum[canonicalJSON(value)] {
+		errors = append(errors, jsonSchemaFailure(pointer, "one_of", "is not an allowed value", n.enumValues...))
+	}
+	if n.consts != nil && *n.consts != canonicalJSON(value) {
+		errors = append(errors, jsonSchemaFailure(pointer, "const", "is not the allowed value", *n.consts))
+	}
+
+	switch valueType {
+	case SchemaNumber:
+		errors = n.validateNumber(errors, value, pointer)
+	case SchemaString:
+		errors = n.validateString(errors, value.(string), pointer)
+	case SchemaArray:
+		errors = n.validateArray(errors, value.([]interface{}), pointer)
+	case SchemaObject:
+		errors = n.validateObject(errors, value.(map[string]interface{}), pointer)
+	}
+
+	return n.validateCombinations(errors, value, pointer, refs)
+}
+
+// hasType determines if the value is of one of the types of the schema
+func (n *jsonSchemaNode) hasType(value interface{}, valueType string) bool {
+	for _, name := range n.types {
+		if name == valueType {
+			return true
+		}
+		if rat, ok := jsonRat(value); ok && name == SchemaInteger && valueType == SchemaNumber && rat.IsInt() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNumber validates the keywords of numbers
+func (n *jsonSchemaNode) validateNumber(errors []ValidationError, value interface{}, pointer string) []ValidationError {
+	number, ok := jsonRat(value)
+	if !ok {
+		return append(errors, jsonSchemaFailure(pointer, "unsupported_number", "is not a supported number"))
+	}
+
+	bounds := []struct {
+		bound   *jsonSchemaNumber
+		invalid func(int) bool
+		code    string
+		message string
+	}{
+		{n.minimum, func(c int) bool { return c < 0 }, "min", "must be greater than or equal to "},
+		{n.exclusiveMinimum, func(c int) bool { return c <= 0 }, "exclusive_min", "must be greater than "},
+		{n.maximum, func(c int) bool { return c > 0 }, "max", "must be less than or equal to "},
+		{n.exclusiveMaximum, func(c int) bool { return c >= 0 }, "exclusive_max", "must be less than "},
+	}
+	for _, b := range bounds {
+		if b.bound != nil && b.invalid(number.Cmp(b.bound.value)) {
+			errors = append(errors, jsonSchemaFailure(pointer, b.code, b.message+b.bound.text, b.bound.text))
+		}
+	}
+
+	if n.multipleOf != nil && !new(big.Rat).Quo(number, n.multipleOf.value).IsInt() {
+		text := n.multipleOf.text
+		errors = append(errors, jsonSchemaFailure(pointer, "multiple_of", "must be a multiple of "+text, text))
+	}
+	return errors
+}
+
+// validateString validates the keywords of strings
+func (n *jsonSchemaNode) validateString(errors []ValidationError, value, pointer string) []ValidationError {
+	length := utf8.RuneCountInString(value)
+	if n.minLength >= 0 && length < n.minLength {
+		count := strconv.Itoa(n.minLength)
+		errors = append(errors, jsonSchemaFailure(pointer, "min_length", "must be at least "+count+" characters", count))
+	}
+	if n.maxLength >= 0 && length > n.maxLength {
+		count := strconv.Itoa(n.maxLength)
+		errors = append(errors, jsonSchemaFailure(pointer, "max_length", "must be no more than "+count+" characters", count))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(value) {
+		pattern := n.pattern.String()
+		errors = append(errors, jsonSchemaFailure(pointer, "pattern", "does not match the pattern "+pattern, pattern))
+	}
+	if n.format != nil && !n.format(value) {
+		failure := jsonSchemaFailure(pointer, "format", "does not match "+n.formatName+" format", n.formatName)
+		if n.formatName == "email" {
+			failure.Err = ErrEmailFormatInvalid
+		}
+		errors = append(errors, failure)
+	}
+	return errors
+}
+
+// validateArray validates the keywords of arrays
+func (n *jsonSchemaNode) validateArray(errors []ValidationError, values []interface{}, pointer string) []ValidationError {
+	if n.minItems >= 0 && len(values) < n.minItems {
+		count := strconv.Itoa(n.minItems)
+		errors = append(errors, jsonSchemaFailure(pointer, "min_items", "must have at least "+count+" items", count))
+	}
+	if n.maxItems >= 0 && len(values) > n.maxItems {
+		count := strconv.Itoa(n.maxItems)
+		errors = append(errors, jsonSchemaFailure(pointer, "max_items", "must have no more than "+count+" items", count))
+	}
+
+	if n.uniqueItems {
+		seen := make(map[string]int, len(values))
+		for i, value := range values {
+			key := canonicalJSON(value)
+			if first, ok := seen[key]; ok {
+				item := strconv.Itoa(first)
+				errors = append(errors, jsonSchemaFailure(jsonPointer(pointer, strconv.Itoa(i)), "unique_items",
+					"is a duplicate of item "+item, item))
+				continue
+			}
+			seen[key] = i
+		}
+	}
+
+	for i, value := range values {
+		itemPointer := jsonPointer(pointer, strconv.Itoa(i))
+		if i < len(n.prefixItems) {
+			errors = n.prefixItems[i].validate(errors, value, itemPointer, 0)
+		} else if n.items != nil {
+			errors = n.items.validate(errors, value, itemPointer, 0)
+		}
+	}
+
+	if n.contains != nil {
+		matches := 0
+		for i, value := range values {
+			if len(n.contains.validate(nil, value, jsonPointer(pointer, strconv.Itoa(i)), 0)) == 0 {
+				matches++
+			}
+		}
+		minContains := 1
+		if n.minContains >= 0 {
+			minContains = n.minContains
+		}
+		if matches < minContains {
+			count := strconv.Itoa(minContains)
+			errors = append(errors, jsonSchemaFailure(pointer, "min_contains",
+				"must contain at least "+count+" matching items", count))
+		}
+		if n.maxContains >= 0 && matches > n.maxContains {
+			count := strconv.Itoa(n.maxContains)
+			errors = append(errors, jsonSchemaFailure(pointer, "max_contains",
+				"must contain no more than "+count+" matching items", count))
+		}
+	}
+	return errors
+}
+
+// validateObject validates the keywords of objects
+func (n *jsonSchemaNode) validateObject(errors []ValidationError, object map[string]interface{}, pointer string) []ValidationError {
+	if n.minProperties >= 0 && len(object) < n.minProperties {
+		count := strconv.Itoa(n.minProperties)
+		errors = append(errors, jsonSchemaFailure(pointer, "min_properties",
+			"must have at least "+count+" properties", count))
+	}
+	if n.maxProperties >= 0 && len(object) > n.maxProperties {
+		count := strconv.Itoa(n.maxProperties)
+		errors = append(errors, jsonSchemaFailure(pointer, "max_properties",
+			"must have no more than "+count+" properties", count))
+	}
+
+	for _, property := range n.required {
+		if _, ok := object[property]; !ok {
+			errors = append(errors, jsonSchemaFailure(jsonPointer(pointer, property), "required", "is required"))
+		}
+	}
+	for _, dependency := range n.dependentRequired {
+		if _, ok := object[dependency.property]; !ok {
+			continue
+		}
+		for _, property := range dependency.required {
+			if _, ok := object[property]; !ok {
+				errors = append(errors, jsonSchemaFailure(jsonPointer(pointer, property), "required_with",
+					"is required when "+dependency.property+" is present", dependency.property))
+			}
+		}
+	}
+
+	// Properties are validated in order, so errors are reported in a deterministic order
+	for _, key := range sortedKeys(object) {
+		value, propertyPointer := object[key], jsonPointer(pointer, key)
+
+		if n.propertyNames != nil {
+			errors = n.propertyNames.validate(errors, key, propertyPointer, 0)
+		}
+
+		matched := false
+		if schema, ok := n.properties[key]; ok {
+			errors, matched = schema.validate(errors, value, propertyPointer, 0), true
+		}
+		for _, pattern := range n.patternProperties {
+			if pattern.pattern.MatchString(key) {
+				errors, matched = pattern.schema.validate(errors, value, propertyPointer, 0), true
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			errors = n.additionalProperties.validate(errors, value, propertyPointer, 0)
+		}
+
+		if schema, ok := n.dependentSchemas[key]; ok {
+			errors = schema.validate(errors, object, pointer, 0)
+		}
+	}
+	return errors
+}
+
+// validateCombinations validates allOf, anyOf, oneOf, not and if/then/else
+func (n *jsonSchemaNode) validateCombinations(errors []ValidationError, value interface{}, pointer string,
+	refs int,
+) []ValidationError {
+	for _, schema := range n.allOf {
+		errors = schema.validate(errors, value, pointer, refs)
+	}
+
+	if len(n.anyOf) > 0 {
+		valid := false
+		for _, schema := range n.anyOf {
+			if len(schema.validate(nil, value, pointer, refs)) == 0 {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			errors = append(errors, jsonSchemaFailure(pointer, "any_of", "must match at least one schema of anyOf"))
+		}
+	}
+
+	if len(n.oneOf) > 0 {
+		matches := 0
+		for _, schema := range n.oneOf {
+			if len(schema.validate(nil, value, pointer, refs)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			count := strconv.Itoa(matches)
+			errors = append(errors, jsonSchemaFailure(pointer, "one_of_schemas",
+				"must match exactly one schema of oneOf, matches "+count, count))
+		}
+	}
+
+	if n.not != nil && len(n.not.validate(nil, value, pointer, refs)) == 0 {
+		errors = append(errors, jsonSchemaFailure(pointer, "not", "must not match the schema of not"))
+	}
+
+	if n.ifSchema != nil {
+		if len(n.ifSchema.validate(nil, value, pointer, refs)) == 0 {
+			if n.thenSchema != nil {
+				errors = n.thenSchema.validate(errors, value, pointer, refs)
+			}
+		} else if n.elseSchema != nil {
+			errors = n.elseSchema.validate(errors, value, pointer, refs)
+		}
+	}
+	return errors
+}
+
+// jsonSchemaFailure creates an error of a keyword for the value at the JSON Pointer
+func jsonSchemaFailure(pointer, code, message string, params ...string) ValidationError {
+	return ValidationError{Key: pointer, Message: message, Code: code, Params: params}
+}
+
+// jsonSchemaError creates a compile error for a keyword of the schema at the location
+func jsonSchemaError(location, keyword, format string, args ...interface{}) *CompileError {
+	return &CompileError{
+		Struct: "json schema",
+		Field:  location,
+		Tag:    keyword,
+		Err:    fmt.Errorf("%w: %s", ErrInvalidJSONSchema, fmt.Sprintf(format, args...)),
+	}
+}
+
+// parseJSONSchemaURI parses the URI of an $id or $ref
+func parseJSONSchemaURI(value interface{}) (*url.URL, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: must be a URI", ErrInvalidJSONSchema)
+	}
+	uri, err := url.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJSONSchema, err.Error())
+	}
+	return uri, nil
+}
+
+// decodeJSON decodes a JSON document, keeping the numbers as json.Number
+func decodeJSON(document []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJSON, err.Error())
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after the document", ErrInvalidJSON)
+	}
+	return value, nil
+}
+
+// jsonType gets the JSON type of a decoded value, "" if it is not a JSON value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return SchemaBoolean
+	case json.Number, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return SchemaNumber
+	case string:
+		return SchemaString
+	case []interface{}:
+		return SchemaArray
+	case map[string]interface{}:
+		return SchemaObject
+	default:
+		return ""
+	}
+}
+
+// jsonRat converts a JSON number to an exact rational number
+func jsonRat(value interface{}) (*big.Rat, bool) {
+	if number, ok := value.(json.Number); ok {
+		text := string(number)
+		if i := strings.IndexAny(text, "eE"); i >= 0 {
+			exponent, err := strconv.Atoi(text[i+1:])
+			if err != nil || exponent > maxJSONNumberExponent || exponent < -maxJSONNumberExponent {
+				return nil, false
+			}
+		}
+		return new(big.Rat).SetString(text)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch kind := reflectValue.Kind(); {
+	case isUintKind(kind):
+		return new(big.Rat).SetUint64(reflectValue.Uint()), true
+	case isFloatKind(kind):
+		if f := reflectValue.Float(); !math.IsInf(f, 0) && !math.IsNaN(f) {
+			return new(big.Rat).SetFloat64(f), true
+		}
+		return nil, false
+	case isNumericKind(kind):
+		return new(big.Rat).SetInt64(reflectValue.Int()), true
+	default:
+		return nil, false
+	}
+}
+
+// jsonCount converts the value of a keyword such as minLength to a non-negative integer
+func jsonCount(value interface{}) (int, bool) {
+	rat, ok := jsonRat(value)
+	if !ok || !rat.IsInt() || rat.Sign() < 0 {
+		return 0, false
+	}
+	if count := rat.Num(); count.IsInt64() && count.Int64() <= math.MaxInt32 {
+		return int(count.Int64()), true
+	}
+	return math.MaxInt32, true
+}
+
+// jsonStrings converts an array of strings
+func jsonStrings(value interface{}) ([]string, bool) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		strs = append(strs, text)
+	}
+	return strs, true
+}
+
+// canonicalJSON encodes a value so equal JSON values (e.g. 1 and 1.0) have the same encoding
+func canonicalJSON(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, canonicalJSON(item))
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	case map[string]interface{}:
+		properties := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			properties = append(properties, strconv.Quote(key)+":"+canonicalJSON(v[key]))
+		}
+		return "{" + strings.Join(properties, ",") + "}"
+	case string:
+		return strconv.Quote(v)
+	}
+
+	if jsonType(value) == SchemaNumber {
+		if rat, ok := jsonRat(value); ok {
+			return rat.RatString()
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// jsonPointer adds a token to a JSON Pointer, escaping ~ and /
+func jsonPointer(pointer, token string) string {
+	return pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// jsonPointerValue gets the value at a JSON Pointer in a document
+func jsonPointerValue(document interface{}, pointer string) (interface{}, bool) {
+	if len(pointer) == 0 {
+		return document, true
+	}
+
+	value := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// sortedKeys gets the keys of a map with string keys in order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
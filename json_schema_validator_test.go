@@ -0,0 +1,339 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderJSONSchema is the JSON Schema of the documents in the tests
+const orderJSONSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$id": "https://example.com/order.json",
+	"type": "object",
+	"required": ["id", "customer", "items"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "string", "format": "uuid"},
+		"customer": {"$ref": "#/$defs/customer"},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"uniqueItems": true,
+			"items": {"$ref": "#item"}
+		},
+		"total": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01},
+		"status": {"enum": ["pending", "shipped"]},
+		"shipping": {
+			"oneOf": [
+				{"type": "object", "required": ["pickup"]},
+				{"type": "object", "required": ["address"]}
+			]
+		},
+		"coupon": {"type": ["string", "null"], "pattern": "^[A-Z]{4}[0-9]{2}$"},
+		"gift": {"type": "boolean"},
+		"message": {"type": "string", "maxLength": 5}
+	},
+	"dependentRequired": {"gift": ["message"]},
+	"$defs": {
+		"customer": {
+			"type": "object",
+			"required": ["email"],
+			"properties": {
+				"email": {"type": "string", "format": "email"},
+				"ip": {"anyOf": [{"format": "ipv4"}, {"format": "ipv6"}]},
+				"website": {"type": "string", "format": "hostname"},
+				"referrer": {"$ref": "#/$defs/customer"}
+			}
+		},
+		"item": {
+			"$anchor": "item",
+			"type": "object",
+			"properties": {
+				"sku": {"type": "string", "minLength": 3},
+				"quantity": {"type": "integer", "minimum": 1, "maximum": 10}
+			},
+			"if": {"properties": {"sku": {"const": "GIFT"}}},
+			"then": {"properties": {"quantity": {"const": 1}}},
+			"not": {"required": ["discount"]}
+		}
+	}
+}`
+
+// TestCompiledJSONSchemaValidate tests validating JSON documents against a JSON Schema
+func TestCompiledJSONSchemaValidate(t *testing.T) {
+	schema, err := CompileJSONSchema([]byte(orderJSONSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		document string
+		expected []ValidationError
+	}{
+		{
+			name: "valid",
+			document: `{
+				"id": "0b7a1b9e-3c1f-4f5e-9a52-2d1c8a4f6b7e",
+				"customer": {"email": "john@domain.com", "ip": "::1", "website": "domain.com", "referrer": {"email": "jane@domain.com"}},
+				"items": [{"sku": "ABC", "quantity": 10}, {"sku": "GIFT", "quantity": 1.0}],
+				"total": 10.25,
+				"status": "pending",
+				"shipping": {"pickup": true},
+				"coupon": null,
+				"gift": true,
+				"message": "Enjoy"
+			}`,
+		},
+		{
+			name:     "missing properties",
+			document: `{}`,
+			expected: []ValidationError{
+				{Key: "/id", Message: "is required"},
+				{Key: "/customer", Message: "is required"},
+				{Key: "/items", Message: "is required"},
+			},
+		},
+		{
+			name: "invalid values with JSON Pointer keys",
+			document: `{
+				"id": "123",
+				"customer": {"email": "invalid", "ip": "localhost", "website": "-", "referrer": {}},
+				"items": [{"sku": "AB", "quantity": 0.5}, {"sku": "GIFT", "quantity": 2}, {"sku": "GIFT", "quantity": 2}],
+				"total": 10.255,
+				"status": "cancelled",
+				"shipping": {"pickup": true, "address": "Main St"},
+				"coupon": "SAVE",
+				"gift": true,
+				"extra/field": 1
+			}`,
+			expected: []ValidationError{
+				{Key: "/message", Message: "is required when gift is present"},
+				{Key: "/coupon", Message: "does not match the pattern ^[A-Z]{4}[0-9]{2}$"},
+				{Key: "/customer/email", Message: "does not match email format"},
+				{Key: "/customer/ip", Message: "must match at least one schema of anyOf"},
+				{Key: "/customer/referrer/email", Message: "is required"},
+				{Key: "/customer/website", Message: "does not match hostname format"},
+				{Key: "/extra~1field", Message: "is not allowed"},
+				{Key: "/id", Message: "does not match uuid format"},
+				{Key: "/items/2", Message: "is a duplicate of item 1"},
+				{Key: "/items/0/quantity", Message: "must be an integer"},
+				{Key: "/items/0/sku", Message: "must be at least 3 characters"},
+				{Key: "/items/1/quantity", Message: "is not the allowed value"},
+				{Key: "/items/2/quantity", Message: "is not the allowed value"},
+				{Key: "/shipping", Message: "must match exactly one schema of oneOf, matches 2"},
+				{Key: "/status", Message: "is not an allowed value"},
+				{Key: "/total", Message: "must be a multiple of 0.01"},
+			},
+		},
+		{
+			name:     "bounds and conditions",
+			document: `{"id": "0b7a1b9e-3c1f-4f5e-9a52-2d1c8a4f6b7e", "customer": {"email": "john@domain.com"}, "items": [{"sku": "ABC", "quantity": 11, "discount": 5}], "total": 0, "message": "Too long"}`,
+			expected: []ValidationError{
+				{Key: "/items/0/quantity", Message: "must be less than or equal to 10"},
+				{Key: "/items/0", Message: "must not match the schema of not"},
+				{Key: "/message", Message: "must be no more than 5 characters"},
+				{Key: "/total", Message: "must be greater than 0"},
+			},
+		},
+		{
+			name:     "wrong types",
+			document: `{"id": 1, "customer": "john", "items": {}, "coupon": 1}`,
+			expected: []ValidationError{
+				{Key: "/coupon", Message: "must be a string or null"},
+				{Key: "/customer", Message: "must be an object"},
+				{Key: "/id", Message: "must be a string"},
+				{Key: "/items", Message: "must be an array"},
+			},
+		},
+		{
+			name:     "not an object",
+			document: `[]`,
+			expected: []ValidationError{{Message: "must be an object"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := schema.Validate([]byte(tt.document))
+			assert.Equal(t, len(tt.expected) == 0, ok)
+			assert.Equal(t, tt.expected, withoutDetails(errs))
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		for _, document := range []string{`{"id":`, `{} {}`} {
+			ok, errs := schema.Validate([]byte(document))
+			assert.False(t, ok)
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Message, "is not valid JSON")
+			assert.Equal(t, "invalid_json", errs[0].Code)
+			require.ErrorIs(t, ValidationErrors(errs), ErrInvalidJSON)
+		}
+	})
+}
+
+// TestCompiledJSONSchemaCodes tests the codes and parameters of the errors of the keywords
+func TestCompiledJSONSchemaCodes(t *testing.T) {
+	schema, err := CompileJSONSchema([]byte(orderJSONSchema))
+	require.NoError(t, err)
+
+	_, errs := schema.Validate([]byte(`{
+		"id": 1,
+		"customer": {"email": "invalid"},
+		"items": [{"sku": "AB", "quantity": 11}],
+		"total": 0,
+		"status": "cancelled",
+		"gift": true
+	}`))
+	assert.Equal(t, []ValidationError{
+		{Key: "/message", Message: "is required when gift is present", Code: "required_with", Params: []string{"gift"}},
+		{
+			Key: "/customer/email", Message: "does not match email format", Code: "format", Params: []string{"email"},
+			Err: ErrEmailFormatInvalid,
+		},
+		{Key: "/id", Message: "must be a string", Code: "type", Params: []string{"string"}},
+		{Key: "/items/0/quantity", Message: "must be less than or equal to 10", Code: "max", Params: []string{"10"}},
+		{Key: "/items/0/sku", Message: "must be at least 3 characters", Code: "min_length", Params: []string{"3"}},
+		{
+			Key: "/status", Message: "is not an allowed value", Code: "one_of",
+			Params: []string{`"pending"`, `"shipped"`},
+		},
+		{Key: "/total", Message: "must be greater than 0", Code: "exclusive_min", Params: []string{"0"}},
+	}, errs)
+
+	// The codes of the rules are translated like the errors of the rules
+	translated := ValidationErrors(errs).Translate(NewTranslator(), "es")
+	assert.Equal(t, "/items/0/sku debe tener al menos 3 caracteres", translated[4].Error())
+
+	// Decoding errors wrap ErrInvalidJSON
+	_, err = CompileJSONSchema([]byte(`{`))
+	require.ErrorIs(t, err, ErrInvalidJSON)
+}
+
+// TestCompiledJSONSchemaKeywords tests the keywords of the core and validation vocabularies
+func TestCompiledJSONSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		expected []ValidationError
+	}{
+		{"true", `true`, `1`, nil},
+		{"false", `false`, `1`, []ValidationError{{Message: "is not allowed"}}},
+		{"large integers are exact", `{"maximum": 9007199254740992}`, `9007199254740993`, []ValidationError{{Message: "must be less than or equal to 9007199254740992"}}},
+		{"exclusive maximum", `{"exclusiveMaximum": 1.5}`, `1.5`, []ValidationError{{Message: "must be less than 1.5"}}},
+		{"huge exponents", `{"type": "number"}`, `1e999999999`, []ValidationError{{Message: "is not a supported number"}}},
+		{"code point length", `{"minLength": 2, "maxLength": 2}`, `"日本"`, nil},
+		{"length ignores other types", `{"minLength": 2}`, `1`, nil},
+		{"date-time", `{"format": "date-time"}`, `"2024-02-30T10:00:00Z"`, []ValidationError{{Message: "does not match date-time format"}}},
+		{"unknown formats are annotations", `{"format": "color"}`, `"red"`, nil},
+		{"enum compares numbers", `{"enum": [1, {"a": [2]}]}`, `{"a": [2.0]}`, nil},
+		{"const", `{"const": {"a": 1}}`, `{"a": 1, "b": 2}`, []ValidationError{{Message: "is not the allowed value"}}},
+		{
+			"prefixItems and items", `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}, "maxItems": 2}`, `["a", "b", 1]`,
+			[]ValidationError{{Message: "must have no more than 2 items"}, {Key: "/1", Message: "must be an integer"}},
+		},
+		{"contains", `{"contains": {"type": "string"}}`, `[1, 2]`, []ValidationError{{Message: "must contain at least 1 matching items"}}},
+		{
+			"min and max contains", `{"contains": {"type": "string"}, "minContains": 0, "maxContains": 1}`, `["a", "b"]`,
+			[]ValidationError{{Message: "must contain no more than 1 matching items"}},
+		},
+		{
+			"pattern properties and names", `{"patternProperties": {"^x-": {"type": "string"}}, "propertyNames": {"maxLength": 3}, "minProperties": 3}`,
+			`{"x-a": 1, "name": "b"}`,
+			[]ValidationError{
+				{Message: "must have at least 3 properties"},
+				{Key: "/name", Message: "must be no more than 3 characters"},
+				{Key: "/x-a", Message: "must be a string"},
+			},
+		},
+		{
+			"dependent schemas", `{"dependentSchemas": {"card": {"required": ["billing"]}}}`, `{"card": 1}`,
+			[]ValidationError{{Key: "/billing", Message: "is required"}},
+		},
+		{
+			"else", `{"if": {"type": "string"}, "then": {"minLength": 1}, "else": {"type": "integer"}}`, `1.5`,
+			[]ValidationError{{Message: "must be an integer"}},
+		},
+		{"recursion follows the document", `{"items": {"$ref": "#"}, "maxItems": 1}`, `[[[1, 2]]]`, []ValidationError{{Key: "/0/0", Message: "must have no more than 1 items"}}},
+		{"reference loops", `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`, `1`, []ValidationError{{Message: "cannot be validated: the schema references loop"}}},
+		{"references outside $defs", `{"properties": {"a": {"$ref": "#/x-types/name"}}, "x-types": {"name": {"type": "string"}}}`, `{"a": 1}`, []ValidationError{{Key: "/a", Message: "must be a string"}}},
+		{"draft 7 definitions", `{"$ref": "#/definitions/positive", "definitions": {"positive": {"minimum": 0}}}`, `-1`, []ValidationError{{Message: "must be greater than or equal to 0"}}},
+		{"escaped pointers", `{"$ref": "#/$defs/a~1b%25", "$defs": {"a/b%": {"type": "null"}}}`, `1`, []ValidationError{{Message: "must be null"}}},
+		{
+			"embedded resources", `{"$id": "https://example.com/root", "$defs": {"b": {"$id": "b", "type": "boolean"}}, "$ref": "b"}`, `1`,
+			[]ValidationError{{Message: "must be a boolean"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := CompileJSONSchema([]byte(tt.schema))
+			require.NoError(t, err)
+
+			ok, errs := schema.Validate([]byte(tt.document))
+			assert.Equal(t, len(tt.expected) == 0, ok)
+			assert.Equal(t, tt.expected, withoutDetails(errs))
+		})
+	}
+
+	t.Run("decoded values", func(t *testing.T) {
+		schema, err := CompileJSONSchema([]byte(`{"properties": {"count": {"type": "integer", "minimum": 1}, "ratio": {"maximum": 1}}}`))
+		require.NoError(t, err)
+
+		ok, errs := schema.ValidateValue(map[string]interface{}{"count": 2.0, "ratio": uint8(1)})
+		assert.True(t, ok)
+		assert.Empty(t, errs)
+
+		_, errs = schema.ValidateValue(map[string]interface{}{"count": 0, "ratio": 1.5})
+		assert.Equal(t, []ValidationError{
+			{Key: "/count", Message: "must be greater than or equal to 1"},
+			{Key: "/ratio", Message: "must be less than or equal to 1"},
+		}, withoutDetails(errs))
+	})
+}
+
+// TestCompileJSONSchemaErrors tests the errors of compiling a JSON Schema
+func TestCompileJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		schema        string
+		expectedError error
+		expectedField string
+		expectedTag   string
+	}{
+		{"not a schema", `1`, ErrInvalidJSONSchema, "#", ""},
+		{"unknown type", `{"properties": {"a": {"type": "date"}}}`, ErrInvalidJSONSchema, "#/properties/a", "type"},
+		{"negative count", `{"minLength": -1}`, ErrInvalidJSONSchema, "#", "minLength"},
+		{"fractional count", `{"maxItems": 1.5}`, ErrInvalidJSONSchema, "#", "maxItems"},
+		{"zero multipleOf", `{"multipleOf": 0}`, ErrInvalidJSONSchema, "#", "multipleOf"},
+		{"invalid pattern", `{"pattern": "("}`, ErrInvalidJSONSchema, "#", "pattern"},
+		{"invalid pattern property", `{"patternProperties": {"(": true}}`, ErrInvalidJSONSchema, "#", "patternProperties"},
+		{"empty allOf", `{"allOf": []}`, ErrInvalidJSONSchema, "#", "allOf"},
+		{"items array", `{"items": [true]}`, ErrInvalidJSONSchema, "#/items", ""},
+		{"required", `{"required": [1]}`, ErrInvalidJSONSchema, "#", "required"},
+		{"unknown reference", `{"$ref": "#/$defs/missing"}`, ErrJSONSchemaRef, "#", "$ref"},
+		{"unknown anchor", `{"$ref": "#missing"}`, ErrJSONSchemaRef, "#", "$ref"},
+		{"remote reference", `{"$ref": "https://example.com/schema.json"}`, ErrJSONSchemaRef, "#", "$ref"},
+		{"unsupported keyword", `{"$defs": {"a": {"unevaluatedProperties": false}}}`, ErrUnsupportedJSONSchema, "#/$defs/a", "unevaluatedProperties"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileJSONSchema([]byte(tt.schema))
+			if !assert.ErrorIs(t, err, tt.expectedError) {
+				return
+			}
+
+			var compileErr *CompileError
+			require.ErrorAs(t, err, &compileErr)
+			assert.Equal(t, "json schema", compileErr.Struct)
+			assert.Equal(t, tt.expectedField, compileErr.Field)
+			assert.Equal(t, tt.expectedTag, compileErr.Tag)
+		})
+	}
+
+	_, err := CompileJSONSchema([]byte(`{`))
+	require.Error(t, err)
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package validate
+
+// raceEnabled is set when the race detector is enabled, which makes sync.Pool drop values (allocating)
+const raceEnabled = false
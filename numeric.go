@@ -19,24 +19,20 @@ type intValueValidation struct {
 }
 
 // Validate is for the intValueValidation type and will compare the integer value (min/max)
-func (i *intValueValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
+func (i *intValueValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return i.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the intValueValidation type and will validate the value without boxing it
+func (i *intValueValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
 	// Compare the value to see if it is convertible to type int64
-	var compareValue int64
-	switch value := value.(type) {
-	case int:
-		compareValue = int64(value)
-	case int8:
-		compareValue = int64(value)
-	case int16:
-		compareValue = int64(value)
-	case int32:
-		compareValue = int64(value)
-	case int64:
-		compareValue = value
-	default:
+	compareValue, ok := intValue(value)
+	if !ok {
 		return &ValidationError{
 			Key:     i.FieldName(),
 			Message: "is not convertible to type int64",
+			Code:    "type",
+			Params:  []string{"int64"},
 		}
 	}
 
@@ -73,24 +69,20 @@ type uintValueValidation struct {
 }
 
 // Validate is for the uintValueValidation type and will compare the unsigned integer value (min/max)
-func (u *uintValueValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
-	// Compare the value to see if it is convertible to type int64
-	var compareValue uint64
-	switch value := value.(type) {
-	case uint:
-		compareValue = uint64(value)
-	case uint8:
-		compareValue = uint64(value)
-	case uint16:
-		compareValue = uint64(value)
-	case uint32:
-		compareValue = uint64(value)
-	case uint64:
-		compareValue = value
-	default:
+func (u *uintValueValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return u.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the uintValueValidation type and will validate the value without boxing it
+func (u *uintValueValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	// Compare the value to see if it is convertible to type uint64
+	compareValue, ok := uintValue(value)
+	if !ok {
 		return &ValidationError{
 			Key:     u.FieldName(),
 			Message: "is not convertible to type uint64",
+			Code:    "type",
+			Params:  []string{"uint64"},
 		}
 	}
 
@@ -127,18 +119,20 @@ type floatValueValidation struct {
 }
 
 // Validate is for the floatValueValidation type and will compare the float value (min/max)
-func (f *floatValueValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
-	// Compare the value to see if it is convertible to type int64
-	var compareValue float64
-	switch value := value.(type) {
-	case float32:
-		compareValue = float64(value)
-	case float64:
-		compareValue = value
-	default:
+func (f *floatValueValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return f.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the floatValueValidation type and will validate the value without boxing it
+func (f *floatValueValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	// Compare the value to see if it is convertible to type float64
+	compareValue, ok := floatValue(value)
+	if !ok {
 		return &ValidationError{
 			Key:     f.FieldName(),
 			Message: "is not convertible to type float64",
+			Code:    "type",
+			Params:  []string{"float64"},
 		}
 	}
 
@@ -162,6 +156,37 @@ func (f *floatValueValidation) Validate(value interface{}, _ reflect.Value) *Val
 	return nil
 }
 
+// intValue gets the value of any type of a signed integer kind, including named types such as
+// `type Cents int64`
+func intValue(value reflect.Value) (int64, bool) {
+	switch value.Kind() { //nolint:exhaustive // only signed integer kinds are convertible
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	default:
+		return 0, false
+	}
+}
+
+// uintValue gets the value of any type of an unsigned integer kind, including named types
+func uintValue(value reflect.Value) (uint64, bool) {
+	switch value.Kind() { //nolint:exhaustive // only unsigned integer kinds are convertible
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), true
+	default:
+		return 0, false
+	}
+}
+
+// floatValue gets the value of any type of a float kind, including named types
+func floatValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() { //nolint:exhaustive // only float kinds are convertible
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
 // minValueValidation creates an interface based on the "kind" type
 func minValueValidation(minValue string, kind reflect.Kind) (Interface, error) {
 	switch kind { //nolint:exhaustive // this is not needed as we are using fallthrough
@@ -279,10 +304,15 @@ var numericValidationsOnce sync.Once //nolint:gochecknoglobals // Validation reg
 // RegisterNumericValidations registers all numeric validations
 func RegisterNumericValidations() {
 	numericValidationsOnce.Do(func() {
-		// Min validation is where X cannot be less than Y
-		AddValidation("min", minValueValidation)
-
-		// Max validation is where X cannot be greater than Y
-		AddValidation("max", maxValueValidation)
+		registerNumericValidations(&DefaultMap)
 	})
 }
+
+// registerNumericValidations registers all numeric validations with the map
+func registerNumericValidations(m *Map) {
+	// Min validation is where X cannot be less than Y
+	m.AddValidation("min", minValueValidation)
+
+	// Max validation is where X cannot be greater than Y
+	m.AddValidation("max", maxValueValidation)
+}
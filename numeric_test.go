@@ -229,8 +229,8 @@ func ExampleIsValid_minInt() {
 	// Will fail since its Quantity = 0
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Quantity must be greater than or equal to 1}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Quantity must be greater than or equal to 1
 }
 
 // ExampleIsValid_MinFloat is an example for Float Value validation (min)
@@ -244,8 +244,8 @@ func ExampleIsValid_minFloat() {
 	// Will fail since its Price = 0
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Price must be greater than or equal to 1E-02}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Price must be greater than or equal to 1E-02
 }
 
 // ExampleIsValid_MaxInt is an example for Int Value validation (max)
@@ -259,8 +259,8 @@ func ExampleIsValid_maxInt() {
 	p.Quantity = 101 // Will fail since it's greater than 99
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Quantity must be less than or equal to 99}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Quantity must be less than or equal to 99
 }
 
 // ExampleIsValid_MaxFloat is an example for Float Value validation (max)
@@ -274,8 +274,8 @@ func ExampleIsValid_maxFloat() {
 	p.Price = 10000.00 // Will fail since it's greater than 999.99
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Price must be less than or equal to 9.9999E+02}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Price must be less than or equal to 9.9999E+02
 }
 
 //
@@ -1274,3 +1274,64 @@ func TestMaxValueFloat64(t *testing.T) {
 		t.Fatal("Valid: 40 is greater than 20", errs)
 	}
 }
+
+// TestNamedNumericTypes tests numeric validations on named types with numeric kinds
+func TestNamedNumericTypes(t *testing.T) {
+	type Cents int64
+
+	type Quantity uint8
+
+	type Ratio float32
+
+	type testModel struct {
+		Price    Cents    `validation:"min=0 max=10000"`
+		Quantity Quantity `validation:"min=1 max=10"`
+		Discount Ratio    `validation:"min=0 max=1"`
+	}
+
+	ok, errs := IsValid(testModel{Price: 500, Quantity: 2, Discount: 0.5})
+	require.True(t, ok)
+	require.Empty(t, errs)
+
+	ok, errs = IsValid(testModel{Price: -1, Quantity: 11, Discount: 1.5})
+	require.False(t, ok)
+	require.Len(t, errs, 3)
+
+	messages := []string{errs[0].Error(), errs[1].Error(), errs[2].Error()}
+	require.Contains(t, messages, "Price must be greater than or equal to 0")
+	require.Contains(t, messages, "Quantity must be less than or equal to 10")
+	require.Contains(t, messages, "Discount must be less than or equal to 1E+00")
+}
+
+// TestNumericValueConversions tests converting values of every numeric kind
+func TestNumericValueConversions(t *testing.T) {
+	type Cents int16
+
+	intTests := []interface{}{int(1), int8(1), int16(1), int32(1), int64(1), Cents(1)}
+	for _, value := range intTests {
+		converted, ok := intValue(reflect.ValueOf(value))
+		require.True(t, ok, "%T should be convertible to int64", value)
+		require.Equal(t, int64(1), converted)
+	}
+
+	uintTests := []interface{}{uint(1), uint8(1), uint16(1), uint32(1), uint64(1)}
+	for _, value := range uintTests {
+		converted, ok := uintValue(reflect.ValueOf(value))
+		require.True(t, ok, "%T should be convertible to uint64", value)
+		require.Equal(t, uint64(1), converted)
+	}
+
+	floatTests := []interface{}{float32(1), float64(1)}
+	for _, value := range floatTests {
+		converted, ok := floatValue(reflect.ValueOf(value))
+		require.True(t, ok, "%T should be convertible to float64", value)
+		require.InDelta(t, 1.0, converted, 0)
+	}
+
+	_, ok := intValue(reflect.ValueOf(uint(1)))
+	require.False(t, ok)
+	_, ok = uintValue(reflect.ValueOf(1))
+	require.False(t, ok)
+	_, ok = floatValue(reflect.ValueOf("1"))
+	require.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// openAPISchemaPrefix is the prefix of the references to the schemas of components
+const openAPISchemaPrefix = "#/components/schemas/"
+
+// OpenAPIExtension creates the extensions (e.g. {"x-mask": "ssn"}) of a rule, added to the schema of
+// the value the rule validates. The names of the extensions must start with "x-".
+type OpenAPIExtension func(rule Rule) map[string]interface{}
+
+// AddOpenAPIExtension registers the extensions of the rules of the validation named key, so
+// project-specific validations can be described in the schemas generated by OpenAPISchemas
+func (m *Map) AddOpenAPIExtension(key string, fn OpenAPIExtension) {
+	m.openAPIExtensions.Store(key, fn)
+}
+
+// OpenAPISchemas generates the OpenAPI 3.1 components.schemas entries of the struct types and of the
+// named structs they use, by type name (qualified by the package on collisions). The schemas are those
+// of JSONSchema, referencing each other with "#/components/schemas/", with the extensions registered
+// with AddOpenAPIExtension. The maps are encoded with sorted keys by encoding/json, so the output is
+// the same for the same types and can be committed:
+//
+//	schemas, err := validate.OpenAPISchemas(reflect.TypeOf(CreateOrder{}), reflect.TypeOf(Customer{}))
+//	document, err := json.MarshalIndent(map[string]interface{}{"components": map[string]interface{}{"schemas": schemas}}, "", "  ")
+func (m *Map) OpenAPISchemas(objectTypes ...reflect.Type) (map[string]interface{}, error) {
+	generator := newJSONSchemaGenerator(m, openAPISchemaPrefix)
+	generator.extensions = true
+
+	for _, objectType := range objectTypes {
+		if objectType == nil || indirectType(objectType).Kind() != reflect.Struct {
+			return nil, &CompileError{Struct: fmt.Sprint(objectType), Err: ErrNotStruct}
+		}
+		if objectType = indirectType(objectType); len(objectType.Name()) == 0 {
+			return nil, &CompileError{Struct: objectType.String(), Err: ErrUnnamedComponent}
+		}
+
+		if _, err := generator.structReference(objectType); err != nil {
+			return nil, err
+		}
+	}
+	return generator.definitions, nil
+}
+
+// addOpenAPIExtensions adds the extensions registered for the rule to the schema
+func (m *Map) addOpenAPIExtensions(schema map[string]interface{}, rule Rule) error {
+	fn, ok := m.openAPIExtensions.Load(rule.Name)
+	if !ok {
+		return nil
+	}
+
+	for name, value := range fn.(OpenAPIExtension)(rule) {
+		if !strings.HasPrefix(name, "x-") {
+			return fmt.Errorf("%w: %s from %q", ErrInvalidOpenAPIExtension, name, rule.Source)
+		}
+		schema[name] = value
+	}
+	return nil
+}
+
+// AddOpenAPIExtension registers the extensions of the rules of a validation with DefaultMap, see Map.AddOpenAPIExtension
+func AddOpenAPIExtension(key string, fn OpenAPIExtension) {
+	DefaultMap.AddOpenAPIExtension(key, fn)
+}
+
+// OpenAPISchemas generates OpenAPI 3.1 component schemas using DefaultMap, see Map.OpenAPISchemas
+func OpenAPISchemas(objectTypes ...reflect.Type) (map[string]interface{}, error) {
+	return DefaultMap.OpenAPISchemas(objectTypes...)
+}
@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openAPIItem is the element of a slice in the components
+type openAPIItem struct {
+	SKU      string `json:"sku" validation:"required min_length=3"`
+	Quantity int    `json:"quantity" validation:"min=1"`
+}
+
+// openAPICustomer is a nested struct of the components
+type openAPICustomer struct {
+	Email string `json:"email" validation:"required format=email"`
+	SSN   string `json:"ssn" validation:"ssn"`
+}
+
+// openAPIOrder is the request type of the components
+type openAPIOrder struct {
+	Customer *openAPICustomer `json:"customer" validation:"required"`
+	Items    []openAPIItem    `json:"items" validation:"required"`
+	Status   string           `json:"status" validation:"one_of=pending,shipped"`
+	Notes    []string         `json:"notes,omitempty" validation:"dive max_length=200"`
+}
+
+// TestMapOpenAPISchemas tests generating the OpenAPI components of types
+func TestMapOpenAPISchemas(t *testing.T) {
+	newMap := func() *Map {
+		m := &Map{}
+		m.AddValidation("min_length", minLengthValidation)
+		m.AddValidation("max_length", maxLengthValidation)
+		m.AddValidation("format", formatValidation)
+		m.AddValidation("min", minValueValidation)
+		m.AddRuleValidation("required", requiredRuleValidation)
+		m.AddRuleValidation("one_of", oneOfRuleValidation)
+		m.AddValidation("ssn", func(string, reflect.Kind) (Interface, error) {
+			return &Validation{}, nil
+		})
+		m.AddOpenAPIExtension("ssn", func(Rule) map[string]interface{} {
+			return map[string]interface{}{"x-mask": "###-##-####", "x-pii": true}
+		})
+		return m
+	}
+
+	schemas, err := newMap().OpenAPISchemas(reflect.TypeOf(openAPIOrder{}), reflect.TypeOf(&openAPICustomer{}))
+	require.NoError(t, err)
+
+	document, err := json.Marshal(map[string]interface{}{"components": map[string]interface{}{"schemas": schemas}})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"components": {"schemas": {
+		"openAPIOrder": {
+			"type": "object",
+			"required": ["customer", "items"],
+			"properties": {
+				"customer": {"$ref": "#/components/schemas/openAPICustomer"},
+				"items": {"type": "array", "items": {"$ref": "#/components/schemas/openAPIItem"}},
+				"status": {"type": "string", "enum": ["pending", "shipped"]},
+				"notes": {"type": "array", "items": {"type": "string", "maxLength": 200}}
+			}
+		},
+		"openAPICustomer": {
+			"type": "object",
+			"required": ["email"],
+			"properties": {
+				"email": {"type": "string", "format": "email"},
+				"ssn": {"type": "string", "x-mask": "###-##-####", "x-pii": true}
+			}
+		},
+		"openAPIItem": {
+			"type": "object",
+			"required": ["sku"],
+			"properties": {
+				"sku": {"type": "string", "minLength": 3},
+				"quantity": {"type": "integer", "minimum": 1}
+			}
+		}
+	}}}`, string(document))
+
+	// The output is the same for each generation, so it can be committed
+	for i := 0; i < 5; i++ {
+		again, err := newMap().OpenAPISchemas(reflect.TypeOf(openAPIOrder{}), reflect.TypeOf(&openAPICustomer{}))
+		require.NoError(t, err)
+		againDocument, err := json.Marshal(map[string]interface{}{"components": map[string]interface{}{"schemas": again}})
+		require.NoError(t, err)
+		assert.Equal(t, string(document), string(againDocument))
+	}
+}
+
+// TestMapOpenAPISchemasErrors tests the errors of generating the OpenAPI components
+func TestMapOpenAPISchemasErrors(t *testing.T) {
+	_, err := OpenAPISchemas(reflect.TypeOf("string"))
+	require.ErrorIs(t, err, ErrNotStruct)
+
+	_, err = OpenAPISchemas(reflect.TypeOf(struct{ Name string }{}))
+	require.ErrorIs(t, err, ErrUnnamedComponent)
+
+	m := &Map{}
+	m.AddValidation("ssn", func(string, reflect.Kind) (Interface, error) {
+		return &Validation{}, nil
+	})
+	m.AddOpenAPIExtension("ssn", func(Rule) map[string]interface{} {
+		return map[string]interface{}{"mask": "###-##-####"}
+	})
+	type Person struct {
+		SSN string `validation:"ssn"`
+	}
+	_, err = m.OpenAPISchemas(reflect.TypeOf(Person{}))
+	require.ErrorIs(t, err, ErrInvalidOpenAPIExtension)
+}
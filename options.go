@@ -0,0 +1,107 @@
+package validate
+
+import "reflect"
+
+// ExpensiveValidation is implemented by validations that are slow to run, such as network lookups
+// (e.g. an MX check). When validating stops early (see Options), they run after the other validations.
+type ExpensiveValidation interface {
+	Interface
+
+	// Expensive marks the validation as expensive
+	Expensive()
+}
+
+// Options change how IsValidOptions validates an object. The zero value runs every validation
+// without active groups, as IsValid does.
+type Options struct {
+	// Groups are the active groups (see IsValidGroups)
+	Groups []string
+
+	// FailFast stops at the first error, as MaxErrors 1 does
+	FailFast bool
+
+	// FieldFailFast stops running the rules of a value (a field, or an element or key of a dive)
+	// after the first that fails. The nested structs and elements it holds are still validated.
+	FieldFailFast bool
+
+	// MaxErrors stops after that many errors, 0 for no limit
+	MaxErrors int
+}
+
+// IsValidOptions determines if an object is valid with the options, e.g. stopping at the first
+// error when only a yes or no answer is needed. When validating stops early, expensive validations
+// (see ExpensiveValidation) run after the other rules of their value, and with FailFast or MaxErrors
+// after all the other rules of the object, so they only run when the limit is not reached without them.
+func (m *Map) IsValidOptions(object interface{}, options Options) (bool, []ValidationError) {
+	return m.validateObject(reflect.ValueOf(object), options.mode())
+}
+
+// IsValidOptions determines if an object is valid with the options using DefaultMap, see Map.IsValidOptions
+func IsValidOptions(object interface{}, options Options) (bool, []ValidationError) {
+	return DefaultMap.IsValidOptions(object, options)
+}
+
+// mode returns the mode validating with the options
+func (o Options) mode() runMode {
+	mode := runMode{groups: groupsKey(o.Groups), maxErrors: o.MaxErrors, fieldFailFast: o.FieldFailFast}
+	if o.FailFast {
+		mode.maxErrors = 1
+	}
+	return mode
+}
+
+// runPhase is the part of the validations a pass over the values runs
+type runPhase int
+
+// Run phases, in the order their passes run
+const (
+	allValidations runPhase = iota
+	cheapValidations
+	expensiveValidations
+)
+
+// runs determines if the phase runs the validation
+func (p runPhase) runs(validation Interface) bool {
+	if p == allValidations {
+		return true
+	}
+	_, expensive := validation.(ExpensiveValidation)
+	return expensive == (p == expensiveValidations)
+}
+
+// runMode is how a pass over the values runs: the active groups (see groupsKey), the validations
+// run, and when it stops (see Options). The zero value runs every validation without groups.
+type runMode struct {
+	groups        string
+	maxErrors     int
+	fieldFailFast bool
+	phase         runPhase
+}
+
+// stops determines if validating can stop early, running the expensive validations last
+func (r runMode) stops() bool {
+	return r.maxErrors > 0 || r.fieldFailFast
+}
+
+// full determines if the errors reached the maximum number of errors
+func (r runMode) full(errors []ValidationError) bool {
+	return r.maxErrors > 0 && len(errors) >= r.maxErrors
+}
+
+// limit drops the errors past the maximum number of errors
+func (r runMode) limit(errors []ValidationError) []ValidationError {
+	if r.full(errors) {
+		return errors[:r.maxErrors]
+	}
+	return errors
+}
+
+// hasKey determines if one of the errors has the key
+func hasKey(errors []ValidationError, key string) bool {
+	for i := range errors {
+		if errors[i].Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lookupValidation is an expensive validation counting its runs, failing for "unknown"
+type lookupValidation struct {
+	Validation
+
+	// runs counts the runs of the validations of a map
+	runs *int
+}
+
+// Expensive marks the lookup as expensive
+func (l *lookupValidation) Expensive() {}
+
+// Validate fails for "unknown"
+func (l *lookupValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
+	*l.runs++
+	if value == "unknown" {
+		return &ValidationError{Key: l.FieldName(), Message: "cannot be found"}
+	}
+	return nil
+}
+
+// optionsMap creates a map with the lookup validation, counting its runs
+func optionsMap(runs *int) *Map {
+	m := NewMap()
+	m.AddValidation("lookup", func(string, reflect.Kind) (Interface, error) {
+		return &lookupValidation{runs: runs}, nil
+	})
+	return m
+}
+
+// optionsDomain is nested in optionsAccount
+type optionsDomain struct {
+	Name string `validation:"lookup min_length=3"`
+}
+
+// optionsAccount has an expensive rule before the cheap rules of its fields
+type optionsAccount struct {
+	Email   string          `validation:"lookup min_length=5 max_length=6"`
+	Name    string          `validation:"required min_length=2"`
+	Domains []optionsDomain `validation:"dive"`
+	Tags    []string        `validation:"dive min_length=2;groups=strict max_length=3;groups=strict"`
+}
+
+// TestMapIsValidOptions tests stopping at the first errors, with the expensive validations last
+func TestMapIsValidOptions(t *testing.T) {
+	runs := 0
+	m := optionsMap(&runs)
+	account := optionsAccount{
+		Email:   "unknown",
+		Name:    "A",
+		Domains: []optionsDomain{{Name: "io"}, {Name: "unknown"}},
+		Tags:    []string{"abcd", "e"},
+	}
+
+	tests := []struct {
+		name     string
+		options  Options
+		expected []ValidationError
+		runs     int
+	}{
+		{"all", Options{}, []ValidationError{
+			{Key: "Email", Message: "cannot be found"},
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+			{Key: "Domains[1].Name", Message: "cannot be found"},
+		}, 3},
+		{"fail fast runs the expensive validations last", Options{FailFast: true}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+		}, 0},
+		{"max errors", Options{MaxErrors: 3}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+		}, 0},
+		{"max errors with expensive validations", Options{MaxErrors: 4}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+			{Key: "Email", Message: "cannot be found"},
+		}, 1},
+		{"field fail fast", Options{FieldFailFast: true}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+			{Key: "Domains[1].Name", Message: "cannot be found"},
+		}, 1},
+		{"field fail fast with max errors", Options{FieldFailFast: true, MaxErrors: 10}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+			{Key: "Domains[1].Name", Message: "cannot be found"},
+		}, 1},
+		{"groups", Options{Groups: []string{"strict"}, FieldFailFast: true, MaxErrors: 5}, []ValidationError{
+			{Key: "Email", Message: "must be no more than 6 characters"},
+			{Key: "Name", Message: "must be at least 2 characters"},
+			{Key: "Domains[0].Name", Message: "must be at least 3 characters"},
+			{Key: "Tags[0]", Message: "must be no more than 3 characters"},
+			{Key: "Tags[1]", Message: "must be at least 2 characters"},
+		}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs = 0
+			ok, errs := m.IsValidOptions(account, test.options)
+			assert.False(t, ok)
+			assert.Equal(t, test.expected, withoutDetails(errs))
+			assert.Equal(t, test.runs, runs)
+		})
+	}
+
+	// Valid objects run every validation
+	runs = 0
+	ok, errs := m.IsValidOptions(optionsAccount{Email: "a@b.io", Name: "Al"}, Options{FailFast: true})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, runs)
+}
+
+// optionsChecks reports several errors of its own
+type optionsChecks struct {
+	Name string `validation:"required"`
+}
+
+// ValidateStruct reports an error for each check
+func (o optionsChecks) ValidateStruct() []ValidationError {
+	return []ValidationError{{Key: "First", Message: "fails"}, {Key: "Second", Message: "fails"}}
+}
+
+// TestMapIsValidOptionsStructValidator tests limiting the errors of ValidateStruct
+func TestMapIsValidOptionsStructValidator(t *testing.T) {
+	m := NewMap()
+	_, errs := m.IsValid(optionsChecks{})
+	require.Len(t, errs, 3)
+
+	_, limited := m.IsValidOptions(optionsChecks{}, Options{MaxErrors: 2})
+	assert.Equal(t, errs[:2], limited)
+	_, limited = m.IsValidOptions(optionsChecks{}, Options{FailFast: true})
+	assert.Equal(t, errs[:1], limited)
+
+	// Compile errors are reported once
+	type Invalid struct {
+		Name string `validation:"unknown_rule"`
+	}
+	_, errs = m.IsValidOptions(Invalid{}, Options{MaxErrors: 5})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, ValidationErrors(errs), ErrUnknownValidation)
+}
+
+// TestIsValidOptions tests validating with the options using DefaultMap
+func TestIsValidOptions(t *testing.T) {
+	InitValidations()
+	ok, errs := IsValidOptions(optionsChecks{}, Options{FailFast: true})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{{Key: "Name", Message: "is required"}}, withoutDetails(errs))
+}
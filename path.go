@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// segmentKind is the way a path segment extends the path before it
+type segmentKind int
+
+// Kinds of path segments
+const (
+	nameSegment   segmentKind = iota // a name, e.g. a field name or the prefix of a root path
+	indexSegment                     // an index of a slice or array, e.g. "[2]"
+	mapKeySegment                    // a key of a map, e.g. "[gold]"
+	structSegment                    // a nested struct, whose fields follow a "."
+)
+
+// pathSegment is a segment of a path
+type pathSegment struct {
+	// name is the name of a nameSegment, or the printed key of a mapKeySegment
+	name string
+
+	// index is the index of an indexSegment
+	index int
+
+	// kind is the way the segment extends the path
+	kind segmentKind
+}
+
+// pathStack holds the segments of the paths of the values being validated. Values are
+// validated depth first, so the path of a value only extends the paths of the values holding it.
+type pathStack struct {
+	segments []pathSegment
+
+	// references are the structs and maps holding the value being validated, see valuePath.enter
+	references []reference
+}
+
+// reference identifies a struct or a map by its type and address
+type reference struct {
+	valueType reflect.Type
+	address   uintptr
+}
+
+// pathPool reuses the stacks of paths, so building paths does not allocate
+var pathPool = sync.Pool{ //nolint:gochecknoglobals // Pool of path stacks
+	New: func() interface{} { return &pathStack{} },
+}
+
+// valuePath is the location of a value in the validated object (e.g. Items[2].Name), as the first
+// segments of a stack. Keys are only built from the segments when errors are reported.
+type valuePath struct {
+	// stack holds the segments of the path, nil for the paths of flat structs (see fieldPath)
+	stack *pathStack
+
+	// length is the number of segments of the path
+	length int
+
+	// field is the name of the field of a path without a stack
+	field string
+}
+
+// fieldPath gets the path of a field of a struct at the path. The fields of a flat struct
+// validated without a stack (see structPlan) are named without one.
+func (p valuePath) fieldPath(name string) valuePath {
+	if p.stack == nil {
+		return valuePath{field: name}
+	}
+	return p.push(pathSegment{name: name})
+}
+
+// getPath gets a root path from the pool, named by the prefix of its keys (if any), see putPath
+func getPath(prefix string) valuePath {
+	root := valuePath{stack: pathPool.Get().(*pathStack)}
+	root.stack.references = root.stack.references[:0]
+	if len(prefix) > 0 {
+		root = root.push(pathSegment{name: prefix})
+	}
+	return root
+}
+
+// putPath returns the stack of a root path to the pool, its paths must no longer be used
+func putPath(root valuePath) {
+	pathPool.Put(root.stack)
+}
+
+// push extends the path with a segment, replacing the segments of the paths that extended it before
+func (p valuePath) push(segment pathSegment) valuePath {
+	p.stack.segments = append(p.stack.segments[:p.length], segment)
+	return valuePath{stack: p.stack, length: p.length + 1}
+}
+
+// enter records that the struct or map is being validated, returning false when it already is, as
+// found through a cycle of pointers (e.g. a child pointing back to its parent). Values that are
+// not addressable cannot be part of a cycle and are not recorded. See leave.
+func (p valuePath) enter(value reflect.Value) (bool, bool) {
+	ref, ok := referenceOf(value)
+	if !ok {
+		return true, false
+	}
+	for _, entered := range p.stack.references {
+		if entered == ref {
+			return false, false
+		}
+	}
+	p.stack.references = append(p.stack.references, ref)
+	return true, true
+}
+
+// referenceOf identifies a struct or a map, false for values that are not addressable
+func referenceOf(value reflect.Value) (reference, bool) {
+	switch {
+	case value.Kind() == reflect.Map:
+		return reference{valueType: value.Type(), address: value.Pointer()}, true
+	case value.CanAddr():
+		return reference{valueType: value.Type(), address: value.UnsafeAddr()}, true
+	}
+	return reference{}, false
+}
+
+// leave removes the struct or map entered last, see enter
+func (p valuePath) leave() {
+	p.stack.references = p.stack.references[:len(p.stack.references)-1]
+}
+
+// String builds the key of the path, e.g. "Items[2].Name"
+func (p valuePath) String() string {
+	if p.stack == nil {
+		return p.field
+	}
+	if p.length == 0 {
+		return ""
+	}
+
+	var key strings.Builder
+	for _, segment := range p.stack.segments[:p.length] {
+		switch segment.kind {
+		case indexSegment:
+			key.WriteString("[" + strconv.Itoa(segment.index) + "]")
+		case mapKeySegment:
+			key.WriteString("[" + segment.name + "]")
+		case structSegment:
+			key.WriteString(".")
+		default:
+			key.WriteString(segment.name)
+		}
+	}
+	return key.String()
+}
+
+// empty determines if the key of the path is empty, without building it
+func (p valuePath) empty() bool {
+	if p.stack == nil {
+		return len(p.field) == 0
+	}
+	for i := 0; i < p.length; i++ {
+		if segment := p.stack.segments[i]; segment.kind != nameSegment || len(segment.name) > 0 {
+			return false
+		}
+	}
+	return true
+}
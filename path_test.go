@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValuePath tests building the keys of paths
+func TestValuePath(t *testing.T) {
+	root := getPath("")
+	defer putPath(root)
+	assert.Empty(t, root.String())
+	assert.True(t, root.empty())
+	assert.True(t, root.push(pathSegment{}).empty())
+
+	items := root.push(pathSegment{name: "Items"})
+	item := items.push(pathSegment{index: 2, kind: indexSegment})
+	name := item.push(pathSegment{kind: structSegment}).push(pathSegment{name: "Name"})
+	assert.Equal(t, "Items[2].Name", name.String())
+	assert.Equal(t, "Items[2]", item.String())
+	assert.False(t, items.empty())
+
+	// Pushing to a path replaces the segments of the paths that extended it
+	tags := item.push(pathSegment{kind: structSegment}).push(pathSegment{name: "Tags"})
+	tag := tags.push(pathSegment{name: "gold", kind: mapKeySegment})
+	assert.Equal(t, "Items[2].Tags[gold]", tag.String())
+	assert.Equal(t, "Items", items.String())
+}
+
+// TestGetPath tests the prefix of root paths
+func TestGetPath(t *testing.T) {
+	root := getPath("Order.")
+	defer putPath(root)
+	assert.Equal(t, "Order.", root.String())
+	assert.Equal(t, "Order.ID", root.push(pathSegment{name: "ID"}).String())
+}
+
+// TestFieldPath tests the paths of fields, with and without a stack
+func TestFieldPath(t *testing.T) {
+	var flat valuePath
+	assert.True(t, flat.empty())
+	assert.Empty(t, flat.String())
+	assert.Equal(t, "Name", flat.fieldPath("Name").String())
+	assert.False(t, flat.fieldPath("Name").empty())
+
+	root := getPath("Order.")
+	defer putPath(root)
+	assert.Equal(t, "Order.Name", root.fieldPath("Name").String())
+}
@@ -0,0 +1,329 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// zeroChecker is implemented by types that know their own zero value, such as time.Time
+type zeroChecker interface {
+	IsZero() bool
+}
+
+// zeroCheckerType is used to find the types implementing zeroChecker
+var zeroCheckerType = reflect.TypeOf((*zeroChecker)(nil)).Elem() //nolint:gochecknoglobals // Type used for zero checks
+
+// requiredValidation type used for values that must not be empty
+type requiredValidation struct {
+	// Validation is the validation interface
+	Validation
+}
+
+// ValidatesPresence marks required as a presence validation, so it runs for nil pointers
+func (r *requiredValidation) ValidatesPresence() {}
+
+// Validate is for the requiredValidation type and will test the value is not empty
+func (r *requiredValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return r.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the requiredValidation type and will validate the value without boxing it
+func (r *requiredValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	if isEmpty(value) {
+		return &ValidationError{
+			Key:     r.FieldName(),
+			Message: "is required",
+		}
+	}
+
+	return nil
+}
+
+// isEmpty determines if a value is empty: the zero value for its kind, a nil pointer (at any
+// level of indirection) or interface, an empty string, slice or map, or a zero time.Time
+// (anything with IsZero). A pointer to an empty value is not empty, it was explicitly set.
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() { //nolint:exhaustive // all other kinds use their zero value
+	case reflect.Invalid:
+		return true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Chan:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		_, absent := indirectValue(value)
+		return absent
+	case reflect.Struct:
+		// Only types with IsZero are boxed to call it, through their address when they have one
+		if value.CanInterface() && value.Type().Implements(zeroCheckerType) {
+			if value.CanAddr() {
+				value = value.Addr()
+			}
+			if zero, ok := value.Interface().(zeroChecker); ok {
+				return zero.IsZero()
+			}
+		}
+	}
+
+	return value.IsZero()
+}
+
+// conditionKind is the way a conditional validation depends on other fields
+type conditionKind int
+
+// Conditional validations
+const (
+	requiredIf conditionKind = iota
+	requiredUnless
+	requiredWith
+	excludedWith
+)
+
+// fieldCondition is another field a conditional validation depends on, and the value it is compared to
+type fieldCondition struct {
+	// fieldName is the name (or dotted path) of the field
+	fieldName string
+
+	// value is the value the field is compared to (required_if and required_unless only)
+	value string
+}
+
+// conditionalValidation type used for values that are required (or excluded) depending on other fields
+type conditionalValidation struct {
+	// Validation is the validation interface
+	Validation
+
+	// fieldNamer names the other fields in the errors
+	fieldNamer
+
+	// conditions are the other fields the validation depends on
+	conditions []fieldCondition
+
+	// kind is the way the validation depends on the conditions
+	kind conditionKind
+
+	// description describes the conditions in error messages (e.g. "when Country is US")
+	description string
+}
+
+// ValidatesPresence marks conditional validations as presence validations, so they run for nil pointers
+func (c *conditionalValidation) ValidatesPresence() {}
+
+// Validate is for the conditionalValidation type and will test the value is present (or empty)
+// when the conditions on the other fields apply
+func (c *conditionalValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return c.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the conditionalValidation type and will test the value without boxing it
+func (c *conditionalValidation) ValidateValue(value, obj reflect.Value) *ValidationError {
+	applies, err := c.applies(obj)
+	if err != nil {
+		return err
+	}
+	if !applies {
+		return nil
+	}
+
+	empty := isEmpty(value)
+	if c.kind == excludedWith && !empty {
+		description, params := c.conditionsDescription(obj)
+		return &ValidationError{
+			Key:     c.FieldName(),
+			Message: "must be empty " + description,
+			Params:  params,
+		}
+	} else if c.kind != excludedWith && empty {
+		description, params := c.conditionsDescription(obj)
+		return &ValidationError{
+			Key:     c.FieldName(),
+			Message: "is required " + description,
+			Params:  params,
+		}
+	}
+
+	return nil
+}
+
+// conditionsDescription describes the conditions with the other fields of obj named as the map
+// names them, returning the parameters of the errors with the named fields (nil for the Go names)
+func (c *conditionalValidation) conditionsDescription(obj reflect.Value) (string, []string) {
+	if c.names == nil {
+		return c.description, nil
+	}
+
+	descriptions := make([]string, len(c.conditions))
+	params := make([]string, 0, 2*len(c.conditions))
+	for i, condition := range c.conditions {
+		name := c.fieldPath(obj, condition.fieldName)
+		descriptions[i] = name
+		params = append(params, name)
+		if c.kind == requiredIf || c.kind == requiredUnless {
+			descriptions[i] += " is " + condition.value
+			params = append(params, condition.value)
+		}
+	}
+	return describeConditions(c.kind, descriptions), params
+}
+
+// describeConditions joins the descriptions of the conditions of a kind (e.g. "when Country is US")
+func describeConditions(kind conditionKind, descriptions []string) string {
+	switch kind {
+	case requiredIf:
+		return "when " + strings.Join(descriptions, " and ")
+	case requiredUnless:
+		return "unless " + strings.Join(descriptions, " and ")
+	case requiredWith, excludedWith:
+		return "when " + strings.Join(descriptions, " or ") + " is present"
+	}
+	return ""
+}
+
+// applies determines if the conditions on the other fields of obj apply
+func (c *conditionalValidation) applies(obj reflect.Value) (bool, *ValidationError) {
+	for _, condition := range c.conditions {
+		field, found := lookupField(obj, condition.fieldName)
+		if !found {
+			return false, &ValidationError{
+				Key:     c.FieldName(),
+				Message: "depends on the unknown field " + condition.fieldName,
+				Code:    "unknown_field",
+				Params:  []string{condition.fieldName},
+			}
+		}
+
+		switch c.kind {
+		case requiredIf, requiredUnless:
+			// All the fields must have their values
+			equal, comparable := fieldEquals(field, condition.value)
+			if !comparable {
+				return false, &ValidationError{
+					Key:     c.FieldName(),
+					Message: "cannot be compared to the field " + condition.fieldName,
+					Code:    "not_comparable",
+					Params:  []string{condition.fieldName},
+				}
+			}
+			if !equal {
+				return c.kind == requiredUnless, nil
+			}
+		case requiredWith, excludedWith:
+			// Any of the fields must be present
+			if !isEmpty(field) {
+				return true, nil
+			}
+		}
+	}
+
+	return c.kind == requiredIf, nil
+}
+
+// fieldEquals determines if the value of a field (through any pointers) equals the literal
+// from a tag, parsing the literal according to the field's kind. Nil pointers equal nothing.
+// False is returned as the second value when the field cannot be compared (e.g. an unexported struct).
+func fieldEquals(field reflect.Value, literal string) (bool, bool) {
+	field, absent := indirectValue(field)
+	if absent {
+		return false, true
+	}
+
+	switch {
+	case field.Kind() == reflect.String:
+		return field.String() == literal, true
+	case field.Kind() == reflect.Bool:
+		value, err := strconv.ParseBool(literal)
+		return err == nil && field.Bool() == value, true
+	case isFloatKind(field.Kind()):
+		value, err := strconv.ParseFloat(literal, 64)
+		return err == nil && field.Float() == value, true
+	case isUintKind(field.Kind()):
+		value, err := strconv.ParseUint(literal, 10, 64)
+		return err == nil && field.Uint() == value, true
+	case isNumericKind(field.Kind()):
+		value, err := strconv.ParseInt(literal, 10, 64)
+		return err == nil && field.Int() == value, true
+	case !field.CanInterface():
+		return false, false
+	default:
+		return fmt.Sprint(field.Interface()) == literal, true
+	}
+}
+
+// conditionalBuilder creates a builder for the conditional validation. required_if and
+// required_unless take pairs of field names and values (e.g. `required_if=Country,US`),
+// while required_with and excluded_with take field names (e.g. `required_with=Street,City`).
+func conditionalBuilder(kind conditionKind) RuleBuilder {
+	return func(rule Rule, _ reflect.Kind) (Interface, error) {
+		validation := &conditionalValidation{kind: kind}
+		descriptions := make([]string, 0, len(rule.Params))
+
+		if kind == requiredIf || kind == requiredUnless {
+			if len(rule.Params) == 0 || len(rule.Params)%2 != 0 {
+				return nil, ErrFieldValuePairsRequired
+			}
+			for i := 0; i < len(rule.Params); i += 2 {
+				if len(rule.Params[i]) == 0 {
+					return nil, ErrFieldValuePairsRequired
+				}
+				validation.conditions = append(validation.conditions, fieldCondition{
+					fieldName: rule.Params[i],
+					value:     rule.Params[i+1],
+				})
+				descriptions = append(descriptions, rule.Params[i]+" is "+rule.Params[i+1])
+			}
+
+			validation.description = describeConditions(kind, descriptions)
+			return validation, nil
+		}
+
+		if len(rule.Params) == 0 {
+			return nil, ErrFieldNameRequired
+		}
+		for _, fieldName := range rule.Params {
+			if len(fieldName) == 0 {
+				return nil, ErrFieldNameRequired
+			}
+			validation.conditions = append(validation.conditions, fieldCondition{fieldName: fieldName})
+		}
+		validation.description = describeConditions(kind, rule.Params)
+
+		return validation, nil
+	}
+}
+
+// requiredRuleValidation creates an interface for the required rule, which takes no parameters
+func requiredRuleValidation(rule Rule, _ reflect.Kind) (Interface, error) {
+	if rule.Params != nil {
+		return nil, ErrNoParameters
+	}
+
+	return &requiredValidation{}, nil
+}
+
+var requiredValidationsOnce sync.Once //nolint:gochecknoglobals // Validation registration synchronization
+
+// RegisterRequiredValidations registers all required validations
+func RegisterRequiredValidations() {
+	requiredValidationsOnce.Do(func() {
+		registerRequiredValidations(&DefaultMap)
+	})
+}
+
+// registerRequiredValidations registers all required validations with the map
+func registerRequiredValidations(m *Map) {
+	// Required validation is where X cannot be empty
+	m.AddRuleValidation("required", requiredRuleValidation)
+
+	// Required if validation is where X cannot be empty when the fields have the values
+	m.AddRuleValidation("required_if", conditionalBuilder(requiredIf))
+
+	// Required unless validation is where X cannot be empty unless the fields have the values
+	m.AddRuleValidation("required_unless", conditionalBuilder(requiredUnless))
+
+	// Required with validation is where X cannot be empty when any of the fields is present
+	m.AddRuleValidation("required_with", conditionalBuilder(requiredWith))
+
+	// Excluded with validation is where X must be empty when any of the fields is present
+	m.AddRuleValidation("excluded_with", conditionalBuilder(excludedWith))
+}
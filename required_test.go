@@ -0,0 +1,357 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsEmpty tests the zero value detection for every kind
+func TestIsEmpty(t *testing.T) {
+	type person struct {
+		Name string
+	}
+
+	name := "John"
+	pointer := &name
+	var nilInterface interface{}
+	var nilPointer *string
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"nil", nil, true},
+		{"empty string", "", true},
+		{"string", "a", false},
+		{"zero int", 0, true},
+		{"int", -1, false},
+		{"zero uint", uint8(0), true},
+		{"uint", uint8(1), false},
+		{"zero float", 0.0, true},
+		{"float", 0.1, false},
+		{"false", false, true},
+		{"true", true, false},
+		{"nil slice", []string(nil), true},
+		{"empty slice", []string{}, true},
+		{"slice", []string{""}, false},
+		{"nil map", map[string]int(nil), true},
+		{"empty map", map[string]int{}, true},
+		{"map", map[string]int{"a": 0}, false},
+		{"zero array", [2]int{}, true},
+		{"array", [2]int{0, 1}, false},
+		{"nil pointer", (*string)(nil), true},
+		{"pointer to empty string", new(string), false},
+		{"pointer", pointer, false},
+		{"zero struct", person{}, true},
+		{"struct", person{Name: "John"}, false},
+		{"zero time", time.Time{}, true},
+		{"time", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"pointer to nil interface", &nilInterface, true},
+		{"pointer to nil pointer", &nilPointer, true},
+		{"pointer to pointer", &pointer, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isEmpty(reflect.ValueOf(tt.value)))
+		})
+	}
+}
+
+// TestRequiredRuleValidation tests building the required validation
+func TestRequiredRuleValidation(t *testing.T) {
+	_, err := requiredRuleValidation(Rule{Name: "required", Params: []string{"true"}}, reflect.String)
+	require.ErrorIs(t, err, ErrNoParameters)
+
+	required, err := requiredRuleValidation(Rule{Name: "required"}, reflect.String)
+	require.NoError(t, err)
+	required.SetFieldName("Name")
+
+	errs := required.Validate("", reflect.Value{})
+	require.NotNil(t, errs)
+	assert.Equal(t, "Name is required", errs.Error())
+
+	assert.Nil(t, required.Validate("John", reflect.Value{}))
+}
+
+// TestRequiredValidation tests the required rule on struct fields of different kinds
+func TestRequiredValidation(t *testing.T) {
+	type Manager struct {
+		Name string `validation:"required"`
+	}
+
+	type Account struct {
+		Name      string            `validation:"required"`
+		Age       int               `validation:"required"`
+		Tags      []string          `validation:"required"`
+		Meta      map[string]string `validation:"required"`
+		Manager   *Manager          `validation:"required"`
+		CreatedAt time.Time         `validation:"required"`
+		Emails    []string          `validation:"dive required"`
+	}
+
+	valid := Account{
+		Name:      "John",
+		Age:       30,
+		Tags:      []string{"admin"},
+		Meta:      map[string]string{"team": "core"},
+		Manager:   &Manager{Name: "Jane"},
+		CreatedAt: time.Now(),
+		Emails:    []string{"john@domain.com"},
+	}
+	ok, errs := IsValid(valid)
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = IsValid(Account{Manager: &Manager{}, Emails: []string{"john@domain.com", ""}})
+	assert.False(t, ok)
+
+	keys := make([]string, 0, len(errs))
+	for _, err := range errs {
+		assert.Equal(t, "is required", err.Message)
+		keys = append(keys, err.Key)
+	}
+	assert.ElementsMatch(t, []string{"Name", "Age", "Tags", "Meta", "Manager.Name", "CreatedAt", "Emails[1]"}, keys)
+
+	_, errs = IsValid(Account{})
+	assert.Contains(t, withoutDetails(errs), ValidationError{Key: "Manager", Message: "is required"})
+}
+
+// TestOmitEmpty tests skipping the rules that follow omitempty for empty values
+func TestOmitEmpty(t *testing.T) {
+	type Address struct {
+		PostalCode string `validation:"min_length=5"`
+	}
+
+	type Profile struct {
+		Nickname string         `validation:"omitempty min_length=5"`
+		Age      int            `validation:"omitempty min=18"`
+		Website  string         `validation:"required omitempty format=email"`
+		Emails   []string       `validation:"omitempty dive format=email"`
+		Address  Address        `validation:"omitempty"`
+		Limits   map[string]int `validation:"dive omitempty min=10"`
+	}
+
+	tests := []struct {
+		name         string
+		profile      Profile
+		expectedKeys []string
+	}{
+		{
+			name:         "empty values skip the rules after omitempty",
+			profile:      Profile{},
+			expectedKeys: []string{"Website"},
+		},
+		{
+			name: "non-empty values run the rules after omitempty",
+			profile: Profile{
+				Nickname: "Jo",
+				Age:      10,
+				Website:  "invalid",
+				Emails:   []string{"invalid"},
+				Address:  Address{PostalCode: "1"},
+			},
+			expectedKeys: []string{"Nickname", "Age", "Website", "Emails[0]", "Address.PostalCode"},
+		},
+		{
+			name: "omitempty on elements",
+			profile: Profile{
+				Website: "john@domain.com",
+				Limits:  map[string]int{"none": 0, "low": 1, "high": 20},
+			},
+			expectedKeys: []string{"Limits[low]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.profile)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+// TestConditionalBuilder tests building the conditional validations
+func TestConditionalBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          conditionKind
+		params        []string
+		expectedError error
+	}{
+		{"required_if pair", requiredIf, []string{"Country", "US"}, nil},
+		{"required_if pairs", requiredIf, []string{"Country", "US", "Type", "business"}, nil},
+		{"required_if empty value", requiredIf, []string{"Country", ""}, nil},
+		{"required_if missing params", requiredIf, nil, ErrFieldValuePairsRequired},
+		{"required_if missing value", requiredIf, []string{"Country"}, ErrFieldValuePairsRequired},
+		{"required_unless empty field name", requiredUnless, []string{"", "US"}, ErrFieldValuePairsRequired},
+		{"required_with fields", requiredWith, []string{"Street", "City"}, nil},
+		{"required_with missing params", requiredWith, nil, ErrFieldNameRequired},
+		{"excluded_with empty field name", excludedWith, []string{"Street", ""}, ErrFieldNameRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation, err := conditionalBuilder(tt.kind)(Rule{Params: tt.params}, reflect.String)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, validation)
+		})
+	}
+}
+
+// TestFieldEquals tests comparing field values to literals from tags
+func TestFieldEquals(t *testing.T) {
+	type AccountType string
+
+	enabled := true
+
+	tests := []struct {
+		name     string
+		field    interface{}
+		literal  string
+		expected bool
+	}{
+		{"string", "US", "US", true},
+		{"different string", "MX", "US", false},
+		{"named string", AccountType("business"), "business", true},
+		{"int", -5, "-5", true},
+		{"int and invalid literal", 5, "five", false},
+		{"uint", uint8(5), "5", true},
+		{"float", 1.5, "1.5", true},
+		{"bool", true, "true", true},
+		{"pointer", &enabled, "true", true},
+		{"nil pointer", (*bool)(nil), "false", false},
+		{"other kinds", []int{1}, "[1]", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, comparable := fieldEquals(reflect.ValueOf(tt.field), tt.literal)
+			assert.True(t, comparable)
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+// TestConditionalValidations tests the conditional validations on struct fields
+func TestConditionalValidations(t *testing.T) {
+	type Address struct {
+		Country string
+		State   string  `validation:"required_if=Country,US"`
+		Zip     *string `validation:"required_unless=Country,MX"`
+	}
+
+	type Signup struct {
+		AccountType string
+		Employees   int
+		CompanyName string `validation:"required_if=AccountType,business,Employees,0"`
+		TaxID       string `validation:"required_with=CompanyName,Address.State"`
+		Nickname    string `validation:"excluded_with=CompanyName"`
+		Address     Address
+	}
+
+	zip := "90210"
+
+	tests := []struct {
+		name           string
+		signup         Signup
+		expectedErrors []string
+	}{
+		{
+			name:   "personal account in Mexico",
+			signup: Signup{AccountType: "personal", Nickname: "Johnny", Address: Address{Country: "MX"}},
+		},
+		{
+			name:   "business account in the US",
+			signup: Signup{AccountType: "business", CompanyName: "Acme", TaxID: "123", Address: Address{Country: "US", State: "CA", Zip: &zip}},
+		},
+		{
+			name:   "required_if with every pair matching",
+			signup: Signup{AccountType: "business", Address: Address{Country: "MX"}},
+			expectedErrors: []string{
+				"CompanyName is required when AccountType is business and Employees is 0",
+			},
+		},
+		{
+			name:   "required_if with one pair not matching",
+			signup: Signup{AccountType: "business", Employees: 5, Address: Address{Country: "MX"}},
+		},
+		{
+			name:   "required_if and required_unless in a nested struct",
+			signup: Signup{AccountType: "personal", Address: Address{Country: "US"}},
+			expectedErrors: []string{
+				"Address.State is required when Country is US",
+				"Address.Zip is required unless Country is MX",
+			},
+		},
+		{
+			name:   "required_with any of the fields present",
+			signup: Signup{AccountType: "personal", Address: Address{Country: "US", State: "CA", Zip: &zip}},
+			expectedErrors: []string{
+				"TaxID is required when CompanyName or Address.State is present",
+			},
+		},
+		{
+			name:   "excluded_with",
+			signup: Signup{AccountType: "business", CompanyName: "Acme", TaxID: "123", Nickname: "Johnny", Address: Address{Country: "MX"}},
+			expectedErrors: []string{
+				"Nickname must be empty when CompanyName is present",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.signup)
+			assert.Equal(t, len(tt.expectedErrors) == 0, ok)
+
+			messages := make([]string, 0, len(errs))
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tt.expectedErrors, messages)
+		})
+	}
+}
+
+// TestConditionalValidationUnknownField tests depending on a field that does not exist
+func TestConditionalValidationUnknownField(t *testing.T) {
+	type Model struct {
+		Name string `validation:"required_with=Missing"`
+	}
+
+	_, errs := IsValid(Model{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Name depends on the unknown field Missing", errs[0].Error())
+}
+
+// TestConditionalValidationUnexportedField tests depending on an unexported field that cannot be compared
+func TestConditionalValidationUnexportedField(t *testing.T) {
+	type Kind struct{ Name string }
+	type Model struct {
+		kind Kind
+		Note string `validation:"required_if=kind,business"`
+	}
+
+	var errs ValidationErrors
+	require.NotPanics(t, func() { _, errs = IsValid(Model{kind: Kind{Name: "business"}}) })
+	require.Len(t, errs, 1)
+	assert.Equal(t, "not_comparable", errs[0].Code)
+	assert.Equal(t, []string{"kind"}, errs[0].Params)
+	assert.Equal(t, "Note cannot be compared to the field kind", errs[0].Error())
+}
@@ -0,0 +1,385 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema types, matching the types of JSON values
+const (
+	SchemaString  = "string"
+	SchemaNumber  = "number"
+	SchemaInteger = "integer"
+	SchemaBoolean = "boolean"
+	SchemaObject  = "object"
+	SchemaArray   = "array"
+)
+
+// schemaTypeOption is the rule declaring the type of a value in SchemaFromTags, e.g. `type=integer min=18`
+const schemaTypeOption = "type"
+
+// schemaValueTypes are the types of the values the validations of each schema type are built for
+var schemaValueTypes = map[string]reflect.Type{ //nolint:gochecknoglobals // Types used for building schema validations
+	"":            reflect.TypeOf((*interface{})(nil)).Elem(),
+	SchemaString:  reflect.TypeOf(""),
+	SchemaNumber:  reflect.TypeOf(float64(0)),
+	SchemaInteger: reflect.TypeOf(int64(0)),
+	SchemaBoolean: reflect.TypeOf(false),
+	SchemaObject:  reflect.TypeOf(map[string]interface{}{}),
+	SchemaArray:   reflect.TypeOf([]interface{}{}),
+}
+
+// Schema describes a loosely typed value, such as JSON decoded into a map[string]interface{}, so it
+// can be validated before it is converted to a Go type. Schemas are written as Go values or built
+// from tags with SchemaFromTags, and compiled with CompileSchema:
+//
+//	schema := &validate.Schema{Type: validate.SchemaObject, Properties: map[string]*validate.Schema{
+//		"email": {Type: validate.SchemaString, Rules: "required format=email"},
+//		"age":   {Type: validate.SchemaInteger, Rules: "min=18"},
+//		"tags":  {Type: validate.SchemaArray, Items: &validate.Schema{Type: validate.SchemaString, Rules: "min_length=2"}},
+//	}}
+//
+// Missing keys and nulls are absent values, so only presence validations (e.g. required) run for them.
+type Schema struct {
+	// Type is the type of the value (see SchemaString, etc.), any type when empty
+	Type string
+
+	// Rules are the validations of the value in the tag syntax (see Rule)
+	Rules string
+
+	// Properties are the schemas of the keys of an object
+	Properties map[string]*Schema
+
+	// Items is the schema of the elements of an array
+	Items *Schema
+}
+
+// CompiledSchema is a schema with its validations built, see Map.CompileSchema
+type CompiledSchema struct {
+	// schemaType is the type of the value
+	schemaType string
+
+	// rules are the validations of the value (dive and keys are not used, see properties and items)
+	rules *ruleSet
+
+	// properties are the schemas of the keys of an object, sorted by key
+	properties []compiledProperty
+
+	// items is the schema of the elements of an array
+	items *CompiledSchema
+}
+
+// compiledProperty is the schema of a key of an object
+type compiledProperty struct {
+	key    string
+	schema *CompiledSchema
+}
+
+// CompileSchema builds the validations of the schema with the registered validations. A *CompileError
+// is returned for the first invalid schema (with the JSONPath of the value as its field).
+func (m *Map) CompileSchema(schema *Schema) (*CompiledSchema, error) {
+	compiled, err := m.compileSchema(schema, "$")
+	if err != nil {
+		return nil, err
+	}
+	return compiled, nil
+}
+
+// compileSchema compiles the schema of the value at the path
+func (m *Map) compileSchema(schema *Schema, path string) (*CompiledSchema, *CompileError) {
+	if schema == nil {
+		return &CompiledSchema{rules: &ruleSet{}}, nil
+	}
+
+	// Values with properties or items are objects or arrays
+	schemaType := schema.Type
+	if len(schemaType) == 0 && len(schema.Properties) > 0 {
+		schemaType = SchemaObject
+	} else if len(schemaType) == 0 && schema.Items != nil {
+		schemaType = SchemaArray
+	}
+
+	valueType, ok := schemaValueTypes[schemaType]
+	switch {
+	case !ok:
+		return nil, newSchemaError(path, schema.Type, fmt.Errorf("%w: %s", ErrUnknownSchemaType, schema.Type))
+	case len(schema.Properties) > 0 && schemaType != SchemaObject, schema.Items != nil && schemaType != SchemaArray:
+		return nil, newSchemaError(path, schema.Type, ErrSchemaTypeConflict)
+	}
+
+	// Build the validations for the type's values
+	compiled := &CompiledSchema{schemaType: schemaType, rules: &ruleSet{}}
+	field := reflect.StructField{Name: path, Type: valueType}
+	if err := m.buildFieldRules(compiled.rules, valueType, field, 0, schema.Rules, nil, nil); err != nil {
+		err.Struct = "schema"
+		return nil, err
+	}
+	if compiled.rules.elements != nil {
+		return nil, newSchemaError(path, schema.Rules, ErrSchemaDive)
+	}
+
+	// Compile the keys in order, so errors are reported in a deterministic order
+	keys := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		property, err := m.compileSchema(schema.Properties[key], jsonPathKey(path, key))
+		if err != nil {
+			return nil, err
+		}
+		compiled.properties = append(compiled.properties, compiledProperty{key: key, schema: property})
+	}
+
+	if schema.Items != nil {
+		items, err := m.compileSchema(schema.Items, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		compiled.items = items
+	}
+
+	return compiled, nil
+}
+
+// newSchemaError creates a compile error for the value of a schema at the path
+func newSchemaError(path, tag string, err error) *CompileError {
+	return &CompileError{Struct: "schema", Field: path, Tag: tag, Err: err}
+}
+
+// Validate determines if the value (e.g. JSON decoded into a map[string]interface{}) is valid.
+// The errors are keyed by the JSONPath of the values (e.g. "$.address.city" or "$.tags[2]").
+func (c *CompiledSchema) Validate(value interface{}) (bool, []ValidationError) {
+	errors := c.validate(nil, reflect.ValueOf(value), reflect.Value{}, "$", "$")
+	return len(errors) == 0, errors
+}
+
+// validate runs the schema against a value of the object parent (at parentPath)
+func (c *CompiledSchema) validate(errors []ValidationError, value, parent reflect.Value,
+	parentPath, path string,
+) []ValidationError {
+	// Empty values skip the validations after omitempty, and have nothing to descend into
+	validations := c.rules.validations
+	omitted := c.rules.omitEmpty && isEmpty(value)
+	if omitted {
+		validations = validations[:c.rules.omitEmptyFrom]
+	}
+
+	// Missing keys and nulls are absent, all other values must be of the schema's type
+	value, absent := indirectValue(value)
+	absent = absent || !value.IsValid()
+
+	var typedValue interface{}
+	if !absent {
+		var ok bool
+		if typedValue, ok = schemaValue(value, c.schemaType); !ok {
+			return append(errors, ValidationError{
+				Key: path, Message: "must be " + schemaTypeName(c.schemaType), Code: "type", Params: []string{c.schemaType},
+				Value: value.Interface(),
+			})
+		}
+	}
+
+	for j, validation := range validations {
+		if _, ok := validation.(PresenceValidation); !ok && absent {
+			continue
+		}
+
+		if err := validation.Validate(typedValue, parent); err != nil {
+			err.describe(c.rules.rule(j), validation.FieldName(), c.rules.label, reflect.ValueOf(typedValue))
+
+			// Errors about the value itself get its path, others (e.g. a compare field) are siblings
+			if err.Key == validation.FieldName() {
+				err.Key = path
+			} else {
+				err.Key = jsonPathKey(parentPath, err.Key)
+			}
+			errors = append(errors, *err)
+		}
+	}
+
+	// Nothing to descend into
+	if omitted || absent {
+		return errors
+	}
+
+	switch c.schemaType {
+	case SchemaObject:
+		for _, property := range c.properties {
+			errors = property.schema.validate(errors, mapValue(value, property.key), value, path,
+				jsonPathKey(path, property.key))
+		}
+	case SchemaArray:
+		if c.items == nil {
+			break
+		}
+		for i := 0; i < value.Len(); i++ {
+			errors = c.items.validate(errors, value.Index(i), parent, parentPath, path+"["+strconv.Itoa(i)+"]")
+		}
+	}
+
+	return errors
+}
+
+// schemaValue converts the value to the Go type the validations of the schema type are built for,
+// numbers to float64 and integers to int64. False is returned when the value is not of the type.
+func schemaValue(value reflect.Value, schemaType string) (interface{}, bool) {
+	// Numbers decoded with json.Decoder.UseNumber
+	if number, ok := value.Interface().(json.Number); ok {
+		switch schemaType {
+		case SchemaNumber:
+			f, err := number.Float64()
+			return f, err == nil
+		case SchemaInteger:
+			i, err := number.Int64()
+			return i, err == nil
+		}
+	}
+
+	kind := value.Kind()
+	switch schemaType {
+	case SchemaString:
+		return value.Interface(), kind == reflect.String
+	case SchemaNumber:
+		if !isNumericKind(kind) {
+			return nil, false
+		}
+		return numberAsFloat(value), true
+	case SchemaInteger:
+		switch {
+		case isFloatKind(kind):
+			// JSON numbers are decoded as float64, whole numbers are integers
+			f := value.Float()
+			return int64(f), f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+		case isUintKind(kind):
+			return int64(value.Uint()), value.Uint() <= math.MaxInt64
+		case isNumericKind(kind):
+			return value.Int(), true
+		default:
+			return nil, false
+		}
+	case SchemaBoolean:
+		return value.Interface(), kind == reflect.Bool
+	case SchemaObject:
+		return value.Interface(), kind == reflect.Map && value.Type().Key().Kind() == reflect.String
+	case SchemaArray:
+		return value.Interface(), kind == reflect.Slice || kind == reflect.Array
+	default:
+		return value.Interface(), true
+	}
+}
+
+// schemaTypeName describes the schema type in error messages
+func schemaTypeName(schemaType string) string {
+	switch schemaType {
+	case SchemaInteger, SchemaObject, SchemaArray:
+		return "an " + schemaType
+	default:
+		return "a " + schemaType
+	}
+}
+
+// mapValue gets the value of the key of a map with string keys, the invalid value when it is missing
+func mapValue(object reflect.Value, key string) reflect.Value {
+	return object.MapIndex(reflect.ValueOf(key).Convert(object.Type().Key()))
+}
+
+// jsonPathKey adds a key to a JSONPath, with the bracket notation for keys that are not identifiers
+func jsonPathKey(path, key string) string {
+	for i, c := range key {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (i == 0 || c < '0' || c > '9') {
+			return path + "['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(key) + "']"
+		}
+	}
+	if len(key) == 0 {
+		return path + "['']"
+	}
+	return path + "." + key
+}
+
+// SchemaFromTags builds a schema from the rules (in the tag syntax) of the values at the paths, where
+// a path is a dotted list of keys and "[]" stands for the elements of an array. The rules can start
+// with the type of the value, and objects and arrays are created for the paths leading to values:
+//
+//	schema, err := validate.SchemaFromTags(map[string]string{
+//		"email":        "type=string required format=email",
+//		"address":      "required",
+//		"address.city": "type=string min_length=2",
+//		"tags[]":       "type=string min_length=2",
+//	})
+//
+// The empty path holds the rules of the value itself.
+func SchemaFromTags(tags map[string]string) (*Schema, error) {
+	root := &Schema{}
+	for path, tag := range tags {
+		schema, err := schemaAtPath(root, path)
+		if err != nil {
+			return nil, newSchemaError(path, tag, err)
+		}
+
+		rules, err := ParseTag(tag)
+		if err != nil {
+			return nil, newSchemaError(path, tag, err)
+		}
+
+		// The type is not a validation
+		sources := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			if rule.Name == schemaTypeOption && len(rule.Params) == 1 {
+				schema.Type = rule.Params[0]
+				continue
+			}
+			sources = append(sources, rule.Source)
+		}
+		schema.Rules = strings.Join(sources, " ")
+	}
+	return root, nil
+}
+
+// schemaAtPath finds (or creates) the schema of the value at the path
+func schemaAtPath(root *Schema, path string) (*Schema, error) {
+	schema := root
+	if len(path) == 0 {
+		return schema, nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		// Count the arrays the key holds (e.g. "matrix[][]")
+		arrays := 0
+		for strings.HasSuffix(key, "[]") {
+			key, arrays = key[:len(key)-2], arrays+1
+		}
+		if strings.ContainsAny(key, "[]") || (len(key) == 0 && (schema != root || arrays == 0)) {
+			return nil, ErrInvalidSchemaPath
+		}
+
+		if len(key) > 0 {
+			if schema.Properties == nil {
+				schema.Properties = map[string]*Schema{}
+			}
+			if schema.Properties[key] == nil {
+				schema.Properties[key] = &Schema{}
+			}
+			schema = schema.Properties[key]
+		}
+
+		for ; arrays > 0; arrays-- {
+			if schema.Items == nil {
+				schema.Items = &Schema{}
+			}
+			schema = schema.Items
+		}
+	}
+	return schema, nil
+}
+
+// CompileSchema builds the validations of the schema using DefaultMap, see Map.CompileSchema
+func CompileSchema(schema *Schema) (*CompiledSchema, error) {
+	return DefaultMap.CompileSchema(schema)
+}
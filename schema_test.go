@@ -0,0 +1,215 @@
+package validate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookSchema is the schema of the payloads in the tests
+func webhookSchema() *Schema {
+	return &Schema{Type: SchemaObject, Rules: "required", Properties: map[string]*Schema{
+		"event":   {Type: SchemaString, Rules: "required format=regexp:^[a-z]+\\.[a-z]+$"},
+		"retries": {Type: SchemaInteger, Rules: "min=0 max=5"},
+		"score":   {Type: SchemaNumber, Rules: "omitempty min=0.5"},
+		"live":    {Type: SchemaBoolean},
+		"email":   {Type: SchemaString, Rules: "required_if=live,true omitempty format=email"},
+		"data": {Rules: "required", Properties: map[string]*Schema{
+			"first-name": {Type: SchemaString, Rules: "min_length=2"},
+			"tags":       {Rules: "omitempty", Items: &Schema{Type: SchemaString, Rules: "min_length=2"}},
+		}},
+	}}
+}
+
+// TestCompiledSchemaValidate tests validating decoded JSON against a schema
+func TestCompiledSchemaValidate(t *testing.T) {
+	schema, err := CompileSchema(webhookSchema())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		payload  string
+		expected []ValidationError
+	}{
+		{
+			name:    "valid",
+			payload: `{"event": "order.created", "retries": 2, "score": 1.5, "live": true, "email": "john@domain.com", "data": {"first-name": "John", "tags": ["ab"]}}`,
+		},
+		{
+			name:    "missing keys and nulls are absent",
+			payload: `{"event": null}`,
+			expected: []ValidationError{
+				{Key: "$.data", Message: "is required"},
+				{Key: "$.event", Message: "is required"},
+			},
+		},
+		{
+			name:    "invalid values with JSONPath keys",
+			payload: `{"event": "created", "retries": 6, "score": 0.1, "live": true, "data": {"first-name": "J", "tags": ["ab", "c"]}}`,
+			expected: []ValidationError{
+				{Key: "$.data['first-name']", Message: "must be at least 2 characters"},
+				{Key: "$.data.tags[1]", Message: "must be at least 2 characters"},
+				{Key: "$.email", Message: "is required when live is true"},
+				{Key: "$.event", Message: "does not match regexp format"},
+				{Key: "$.retries", Message: "must be less than or equal to 5"},
+				{Key: "$.score", Message: "must be greater than or equal to 5E-01"},
+			},
+		},
+		{
+			name:    "values of the wrong type",
+			payload: `{"event": 1, "retries": 1.5, "score": "high", "live": "yes", "data": {"tags": "ab"}}`,
+			expected: []ValidationError{
+				{Key: "$.data.tags", Message: "must be an array"},
+				{Key: "$.event", Message: "must be a string"},
+				{Key: "$.live", Message: "must be a boolean"},
+				{Key: "$.retries", Message: "must be an integer"},
+				{Key: "$.score", Message: "must be a number"},
+			},
+		},
+		{
+			name:     "not an object",
+			payload:  `[]`,
+			expected: []ValidationError{{Key: "$", Message: "must be an object"}},
+		},
+		{
+			name:     "null",
+			payload:  `null`,
+			expected: []ValidationError{{Key: "$", Message: "is required"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &payload))
+
+			ok, errs := schema.Validate(payload)
+			assert.Equal(t, len(tt.expected) == 0, ok)
+			assert.Equal(t, tt.expected, withoutDetails(errs))
+		})
+	}
+}
+
+// TestCompiledSchemaValidateValues tests values not decoded from JSON
+func TestCompiledSchemaValidateValues(t *testing.T) {
+	schema, err := CompileSchema(&Schema{Properties: map[string]*Schema{
+		"count": {Type: SchemaInteger, Rules: "min=1"},
+		"ratio": {Type: SchemaNumber, Rules: "max=1"},
+		"limit": {Type: SchemaInteger, Rules: "gte_field=count"},
+	}})
+	require.NoError(t, err)
+
+	ok, errs := schema.Validate(map[string]interface{}{"count": uint8(2), "ratio": json.Number("0.5"), "limit": json.Number("3")})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	_, errs = schema.Validate(map[string]interface{}{"count": 2, "limit": 1, "ratio": json.Number("abc")})
+	assert.Equal(t, []ValidationError{
+		{Key: "$.limit", Message: "must be greater than or equal to count"},
+		{Key: "$.ratio", Message: "must be a number"},
+	}, withoutDetails(errs))
+
+	// Strings are compared to the other keys of the object
+	confirmed, err := CompileSchema(&Schema{Properties: map[string]*Schema{
+		"password": {Type: SchemaString, Rules: "compare=confirm"},
+		"confirm":  {Type: SchemaString},
+	}})
+	require.NoError(t, err)
+	ok, _ = confirmed.Validate(map[string]interface{}{"password": "a", "confirm": "a"})
+	assert.True(t, ok)
+	_, errs = confirmed.Validate(map[string]interface{}{"password": "a", "confirm": "b"})
+	assert.Equal(t, []ValidationError{
+		{Key: "$.password", Message: "is not the same as the compare field confirm"},
+	}, withoutDetails(errs))
+	_, errs = confirmed.Validate(map[string]interface{}{"password": "a"})
+	assert.Equal(t, []ValidationError{
+		{Key: "$.confirm", Message: "is not of type string and StringEqualsValidation only accepts strings"},
+	}, withoutDetails(errs))
+
+	// Pointers are followed
+	count := 0
+	_, errs = schema.Validate(&map[string]interface{}{"count": &count})
+	assert.Equal(t, []ValidationError{{
+		Key: "$.count", Message: "must be greater than or equal to 1", Code: "min", Params: []string{"1"}, Value: int64(0),
+	}}, errs)
+}
+
+// TestCompileSchemaErrors tests the errors of compiling a schema
+func TestCompileSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		schema        *Schema
+		expectedError error
+		expectedField string
+	}{
+		{"unknown type", &Schema{Type: "date"}, ErrUnknownSchemaType, "$"},
+		{"properties of an array", &Schema{Type: SchemaArray, Properties: map[string]*Schema{"a": {}}}, ErrSchemaTypeConflict, "$"},
+		{"items of an object", &Schema{Type: SchemaObject, Items: &Schema{}}, ErrSchemaTypeConflict, "$"},
+		{"dive", &Schema{Type: SchemaArray, Rules: "dive min_length=1"}, ErrSchemaDive, "$"},
+		{"unknown validation", &Schema{Properties: map[string]*Schema{"a b": {Rules: "unknown"}}}, ErrUnknownValidation, "$['a b']"},
+		{"invalid rule in items", &Schema{Items: &Schema{Type: SchemaString, Rules: "keys"}}, ErrInvalidKeys, "$[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileSchema(tt.schema)
+			if !assert.ErrorIs(t, err, tt.expectedError) {
+				return
+			}
+
+			var compileErr *CompileError
+			require.ErrorAs(t, err, &compileErr)
+			assert.Equal(t, "schema", compileErr.Struct)
+			assert.Equal(t, tt.expectedField, compileErr.Field)
+		})
+	}
+}
+
+// TestSchemaFromTags tests building schemas from tags
+func TestSchemaFromTags(t *testing.T) {
+	schema, err := SchemaFromTags(map[string]string{
+		"":             "required",
+		"email":        "type=string required format=email",
+		"address":      "required",
+		"address.city": "type=string min_length=2",
+		"matrix[][]":   "type=integer min=0",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Schema{Rules: "required", Properties: map[string]*Schema{
+		"email":   {Type: SchemaString, Rules: "required format=email"},
+		"address": {Rules: "required", Properties: map[string]*Schema{"city": {Type: SchemaString, Rules: "min_length=2"}}},
+		"matrix":  {Items: &Schema{Items: &Schema{Type: SchemaInteger, Rules: "min=0"}}},
+	}}, schema)
+
+	compiled, err := CompileSchema(schema)
+	require.NoError(t, err)
+
+	var payload interface{}
+	require.NoError(t, json.NewDecoder(strings.NewReader(`{"email": "invalid", "address": {"city": "D"}, "matrix": [[1], [2, -1]]}`)).Decode(&payload))
+	_, errs := compiled.Validate(payload)
+	assert.Equal(t, []ValidationError{
+		{Key: "$.address.city", Message: "must be at least 2 characters"},
+		{Key: "$.email", Message: "does not match email format"},
+		{Key: "$.matrix[1][1]", Message: "must be greater than or equal to 0"},
+	}, withoutDetails(errs))
+
+	t.Run("root array", func(t *testing.T) {
+		schema, err := SchemaFromTags(map[string]string{"[]": "type=string"})
+		require.NoError(t, err)
+		assert.Equal(t, &Schema{Items: &Schema{Type: SchemaString}}, schema)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, path := range []string{"a..b", "a.[]", "a[0]", "[]b"} {
+			_, err := SchemaFromTags(map[string]string{path: "required"})
+			require.ErrorIs(t, err, ErrInvalidSchemaPath, path)
+		}
+
+		_, err := SchemaFromTags(map[string]string{"a": "format='email"})
+		require.ErrorIs(t, err, ErrInvalidSpecification)
+	})
+}
@@ -21,12 +21,19 @@ type maxLengthStringValidation struct {
 }
 
 // Validate is for the maxLengthStringValidation type and will test the max string length
-func (m *maxLengthStringValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
-	strValue, ok := value.(string)
+func (m *maxLengthStringValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return m.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the maxLengthStringValidation type and will validate the value without boxing it
+func (m *maxLengthStringValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	strValue, ok := stringValue(value)
 	if !ok {
 		return &ValidationError{
 			Key:     m.FieldName(),
 			Message: "is not of type string and MaxLengthValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
 		}
 	}
 
@@ -50,12 +57,19 @@ type minLengthStringValidation struct {
 }
 
 // Validate is for the minLengthStringValidation type and will test the min string length
-func (m *minLengthStringValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
-	strValue, ok := value.(string)
+func (m *minLengthStringValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return m.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the minLengthStringValidation type and will validate the value without boxing it
+func (m *minLengthStringValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	strValue, ok := stringValue(value)
 	if !ok {
 		return &ValidationError{
 			Key:     m.FieldName(),
 			Message: "is not of type string and MinLengthValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
 		}
 	}
 
@@ -82,67 +96,148 @@ type formatStringValidation struct {
 }
 
 // Validate is for the formatStringValidation type and will test the given regular expression
-func (f *formatStringValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
-	strValue, ok := value.(string)
+func (f *formatStringValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return f.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the formatStringValidation type and will validate the value without boxing it
+func (f *formatStringValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	strValue, ok := stringValue(value)
 	if !ok {
 		return &ValidationError{
 			Key:     f.FieldName(),
 			Message: "is not of type string and FormatValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
 		}
 	}
 
 	if !f.pattern.MatchString(strValue) {
-		return &ValidationError{
+		err := &ValidationError{
 			Key:     f.FieldName(),
 			Message: "does not match " + f.patternName + " format",
 		}
+		if f.pattern == emailRegex {
+			err.Err = ErrEmailFormatInvalid
+		}
+		return err
 	}
 
 	return nil
 }
 
+// oneOfStringValidation type used for strings that must be one of the allowed values
+type oneOfStringValidation struct {
+	// Validation is the validation interface
+	Validation
+
+	// allowed are the allowed values, in the order of the rule
+	allowed []string
+}
+
+// Validate is for the oneOfStringValidation type and will test the value is one of the allowed values
+func (o *oneOfStringValidation) Validate(value interface{}, obj reflect.Value) *ValidationError {
+	return o.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the oneOfStringValidation type and will validate the value without boxing it
+func (o *oneOfStringValidation) ValidateValue(value, _ reflect.Value) *ValidationError {
+	strValue, ok := stringValue(value)
+	if !ok {
+		return &ValidationError{
+			Key:     o.FieldName(),
+			Message: "is not of type string and OneOfValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
+		}
+	}
+
+	for _, allowed := range o.allowed {
+		if strValue == allowed {
+			return nil
+		}
+	}
+
+	return &ValidationError{
+		Key:     o.FieldName(),
+		Message: "must be one of " + strings.Join(o.allowed, ", "),
+		Err:     ErrEnumValueNotAllowed,
+	}
+}
+
 // stringEqualsString string equals string struct
 type stringEqualsString struct {
 	// Validation is the validation interface
 	Validation
 
+	// fieldNamer names the target field in the errors
+	fieldNamer
+
 	// targetFieldName is the target field name to compare
 	targetFieldName string
 }
 
 // Validate is for the stringEqualsString type and will test the given field's value and compare
 func (s *stringEqualsString) Validate(value interface{}, obj reflect.Value) *ValidationError {
-	strValue, ok := value.(string)
+	return s.ValidateValue(reflect.ValueOf(value), obj)
+}
+
+// ValidateValue is for the stringEqualsString type and will validate the value without boxing it
+func (s *stringEqualsString) ValidateValue(value, obj reflect.Value) *ValidationError {
+	strValue, ok := stringValue(value)
 	if !ok {
 		return &ValidationError{
 			Key:     s.FieldName(),
 			Message: "is not of type string and StringEqualsStringValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
 		}
 	}
 
-	// Set the field name
-	compareField := obj.FieldByName(s.targetFieldName)
+	// Find the field of the struct, or the key of a Schema map (there is none in a ValueRule)
+	compareField, found := lookupField(obj, s.targetFieldName)
+	if !found {
+		return &ValidationError{
+			Key:     s.FieldName(),
+			Message: "cannot be compared to the unknown field " + s.targetFieldName,
+			Code:    "unknown_field",
+			Params:  []string{s.targetFieldName},
+		}
+	}
+	compareField, _ = indirectValue(compareField)
 
 	// Try to set to string
-	compareFieldStrValue, ok := compareField.Interface().(string)
-	if !ok {
+	if compareField.Kind() != reflect.String {
 		return &ValidationError{
-			Key:     s.targetFieldName,
+			Key:     s.fieldPath(obj, s.targetFieldName),
 			Message: "is not of type string and StringEqualsValidation only accepts strings",
+			Code:    "type",
+			Params:  []string{"string"},
 		}
 	}
 
 	// Does not compare
-	if strValue != compareFieldStrValue {
+	if strValue != compareField.String() {
+		target := s.fieldPath(obj, s.targetFieldName)
 		return &ValidationError{
 			Key:     s.FieldName(),
-			Message: "is not the same as the compare field " + s.targetFieldName,
+			Message: "is not the same as the compare field " + target,
+			Params:  []string{target},
 		}
 	}
 
 	return nil
 }
 
+// stringValue gets the value of any type of the string kind, including named types such as
+// `type EmailAddress string`
+func stringValue(value reflect.Value) (string, bool) {
+	if value.Kind() != reflect.String {
+		return "", false
+	}
+	return value.String(), true
+}
+
 // maxLengthValidation creates an interface based on the max length value
 func maxLengthValidation(maxLength string, _ reflect.Kind) (Interface, error) {
 	length, err := strconv.ParseInt(maxLength, 10, 0)
@@ -190,6 +285,17 @@ func formatValidation(options string, _ reflect.Kind) (Interface, error) {
 	return nil, &ValidationError{Key: "format", Message: "Has no pattern " + options}
 }
 
+// oneOfRuleValidation creates an interface based on the allowed values, e.g. `one_of=pending,'on hold'`
+func oneOfRuleValidation(rule Rule, _ reflect.Kind) (Interface, error) {
+	if len(rule.Params) == 0 {
+		return nil, ErrAllowedValuesRequired
+	}
+
+	return &oneOfStringValidation{
+		allowed: rule.Params,
+	}, nil
+}
+
 // stringEqualsStringValidation creates an interface based on the field name
 func stringEqualsStringValidation(fieldName string, _ reflect.Kind) (Interface, error) {
 	return &stringEqualsString{
@@ -202,16 +308,24 @@ var stringValidationsOnce sync.Once //nolint:gochecknoglobals // Validation regi
 // RegisterStringValidations registers all string validations
 func RegisterStringValidations() {
 	stringValidationsOnce.Do(func() {
-		// Max length validation is len(string) < X
-		AddValidation("max_length", maxLengthValidation)
+		registerStringValidations(&DefaultMap)
+	})
+}
 
-		// Min length validation is len(string) > X
-		AddValidation("min_length", minLengthValidation)
+// registerStringValidations registers all string validations with the map
+func registerStringValidations(m *Map) {
+	// Max length validation is len(string) < X
+	m.AddValidation("max_length", maxLengthValidation)
 
-		// Format validation uses a given regular expression to match
-		AddValidation("format", formatValidation)
+	// Min length validation is len(string) > X
+	m.AddValidation("min_length", minLengthValidation)
 
-		// Compare validation uses another field to compare
-		AddValidation("compare", stringEqualsStringValidation)
-	})
+	// Format validation uses a given regular expression to match
+	m.AddValidation("format", formatValidation)
+
+	// Compare validation uses another field to compare
+	m.AddValidation("compare", stringEqualsStringValidation)
+
+	// One of validation accepts only the listed values
+	m.AddRuleValidation("one_of", oneOfRuleValidation)
 }
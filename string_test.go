@@ -175,8 +175,8 @@ func ExampleIsValid_maxLength() {
 	p.Gender = "This is invalid!" // Will fail since it's > 10 characters
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Gender must be no more than 10 characters}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Gender must be no more than 10 characters
 }
 
 //
@@ -265,8 +265,8 @@ func ExampleIsValid_minLength() {
 	// Will fail since it's < 1 character
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Gender must be at least 1 characters}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Gender must be at least 1 characters
 }
 
 //
@@ -387,8 +387,8 @@ func ExampleIsValid_formatEmail() {
 	// Will fail since the email is not valid
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Email does not match email format}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Email does not match email format
 }
 
 // TestFormatRegExp tests regex format (invalid and valid formats)
@@ -446,8 +446,8 @@ func ExampleIsValid_formatRegEx() {
 	// Will fail since the email is not valid
 
 	ok, errs := IsValid(p)
-	fmt.Println(ok, errs)
-	// Output: false [{Phone does not match regexp format}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Phone does not match regexp format
 }
 
 //
@@ -546,6 +546,72 @@ func ExampleIsValid_compareString() {
 	u.PasswordConfirmation = "That"
 
 	ok, errs := IsValid(u)
-	fmt.Println(ok, errs)
-	// Output: false [{Password is not the same as the compare field PasswordConfirmation}]
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Password is not the same as the compare field PasswordConfirmation
+}
+
+// TestOneOf tests values that must be one of the allowed values
+func TestOneOf(t *testing.T) {
+	type Status string
+
+	type testModel struct {
+		Status Status `validation:"one_of=pending,'on hold',shipped"`
+		Count  int    `validation:"one_of=1,2"`
+	}
+
+	ok, errs := IsValid(testModel{Status: "on hold"})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "Count", Message: "is not of type string and OneOfValidation only accepts strings"},
+	}, withoutDetails(errs))
+
+	_, errs = IsValid(&testModel{Status: "cancelled"})
+	require.NotEmpty(t, errs)
+	assert.Equal(t, "Status must be one of pending, on hold, shipped", errs[0].Error())
+	require.ErrorIs(t, &errs[0], ErrEnumValueNotAllowed)
+
+	_, err := oneOfRuleValidation(Rule{Name: "one_of"}, reflect.String)
+	require.ErrorIs(t, err, ErrAllowedValuesRequired)
+}
+
+// ExampleIsValid_oneOf is an example for one of validation
+func ExampleIsValid_oneOf() {
+	type Order struct {
+		// Status must be one of the known statuses
+		Status string `validation:"one_of=pending,shipped"`
+	}
+
+	ok, errs := IsValid(Order{Status: "lost"})
+	fmt.Println(ok, ValidationErrors(errs).Error())
+	// Output: false Status must be one of pending, shipped
+}
+
+// TestNamedStringTypes tests string validations on named types with the string kind
+func TestNamedStringTypes(t *testing.T) {
+	type EmailAddress string
+
+	type Username string
+
+	type testModel struct {
+		Email        EmailAddress `validation:"format=email"`
+		Username     Username     `validation:"min_length=3 max_length=8"`
+		Confirmation Username     `validation:"compare=Username"`
+	}
+
+	ok, errs := IsValid(testModel{Email: "john@domain.com", Username: "johnny", Confirmation: "johnny"})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = IsValid(testModel{Email: "invalid", Username: "jo", Confirmation: "johnny"})
+	assert.False(t, ok)
+	require.Len(t, errs, 3)
+	assert.ElementsMatch(t, []string{
+		"Email does not match email format",
+		"Username must be at least 3 characters",
+		"Confirmation is not the same as the compare field Username",
+	}, []string{errs[0].Error(), errs[1].Error(), errs[2].Error()})
+
+	_, errs = IsValid(testModel{Email: "john@domain.com", Username: "johnny_too_long", Confirmation: "johnny_too_long"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Username must be no more than 8 characters", errs[0].Error())
 }
@@ -0,0 +1,232 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rule is a single validation parsed from a validation tag, such as `min_length=5` or `required`
+//
+// The tag grammar is a list of rules separated by spaces, where each rule is a name optionally
+// followed by "=" and comma separated parameters, and by options each starting with ";":
+//
+//	validation:"required min_length=5 format='regexp:^[A-Z][a-z]+ [A-Z][a-z]+$' required_if=Country,US"
+//	validation:"required;groups=create min_length=8;groups=create,update"
+//
+// Parameters can be quoted with single or double quotes to include spaces, commas and semicolons,
+// and a backslash escapes a space, comma, semicolon, quote or backslash. Any other backslash is
+// kept as is, so regular expressions such as `^\d+$` do not need to be escaped twice.
+//
+// An unquoted regular expression of format (e.g. `format=regexp:^[^';]+$`) is read as is until
+// whitespace, as tags were read before quotes and options, so quotes, commas, semicolons and
+// backslashes are part of the expression, which is the single parameter. Quote the expression to follow it with options.
+type Rule struct {
+	// Name is the validation name (e.g. "min_length")
+	Name string
+
+	// Params are the comma separated parameters with quotes and escapes removed, nil when there is no "="
+	Params []string
+
+	// Raw is the parameter text with quotes and escapes removed, but commas kept
+	Raw string
+
+	// Options are the options of the rule by name with their parameters (e.g. "groups"), nil when there are none
+	Options map[string][]string
+
+	// Source is the rule as written in the tag
+	Source string
+
+	// Column is the position (starting at 1) of the rule in the tag
+	Column int
+}
+
+// TagSyntaxError describes a validation tag that could not be parsed
+type TagSyntaxError struct {
+	// Tag is the full validation tag
+	Tag string
+
+	// Column is the position (starting at 1) of the error in the tag
+	Column int
+
+	// Message describes the error
+	Message string
+}
+
+// Error returns a string of the column and message
+func (t *TagSyntaxError) Error() string {
+	return "syntax error at column " + strconv.Itoa(t.Column) + ": " + t.Message
+}
+
+// Unwrap returns ErrInvalidSpecification so errors.Is can be used
+func (t *TagSyntaxError) Unwrap() error {
+	return ErrInvalidSpecification
+}
+
+// ParseTag parses a validation tag into its rules, see Rule for the grammar.
+// A *TagSyntaxError is returned when the tag is malformed.
+func ParseTag(tag string) ([]Rule, error) {
+	var rules []Rule
+	for position := 0; ; {
+		// Skip the separating whitespace
+		for position < len(tag) && isTagSpace(tag[position]) {
+			position++
+		}
+		if position == len(tag) {
+			return rules, nil
+		}
+
+		rule, next, err := parseRule(tag, position)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+		position = next
+	}
+}
+
+// parseRule parses the rule starting at the position, returning the position after it
+func parseRule(tag string, start int) (Rule, int, error) {
+	rule := Rule{Column: start + 1}
+
+	// Read the name
+	position := readTagName(tag, start)
+	rule.Name = tag[start:position]
+	if len(rule.Name) == 0 {
+		return rule, 0, &TagSyntaxError{Tag: tag, Column: position + 1, Message: "expected a validation name"}
+	}
+
+	// Read the parameters (e.g. =5)
+	var err error
+	if position < len(tag) && tag[position] == '=' && isRawPattern(rule.Name, tag[position+1:]) {
+		rule.Params, rule.Raw, position = parseRawParams(tag, position+1)
+	} else if position < len(tag) && tag[position] == '=' {
+		if rule.Params, rule.Raw, position, err = parseParams(tag, position+1); err != nil {
+			return rule, 0, err
+		}
+	} else if err = expectRuleEnd(tag, position, "validation name"); err != nil {
+		return rule, 0, err
+	}
+
+	// Read the options (e.g. ;groups=create,update)
+	for position < len(tag) && tag[position] == ';' {
+		nameStart := position + 1
+		position = readTagName(tag, nameStart)
+		name := tag[nameStart:position]
+		if len(name) == 0 {
+			return rule, 0, &TagSyntaxError{Tag: tag, Column: position + 1, Message: "expected an option name"}
+		}
+		if _, ok := rule.Options[name]; ok {
+			return rule, 0, &TagSyntaxError{Tag: tag, Column: nameStart + 1, Message: "duplicate option " + strconv.Quote(name)}
+		}
+
+		var params []string
+		if position < len(tag) && tag[position] == '=' {
+			if params, _, position, err = parseParams(tag, position+1); err != nil {
+				return rule, 0, err
+			}
+		} else if err = expectRuleEnd(tag, position, "option name"); err != nil {
+			return rule, 0, err
+		}
+
+		if rule.Options == nil {
+			rule.Options = map[string][]string{}
+		}
+		rule.Options[name] = params
+	}
+
+	rule.Source = tag[start:position]
+	return rule, position, nil
+}
+
+// readTagName returns the position after the name starting at the position
+func readTagName(tag string, position int) int {
+	for position < len(tag) && isTagNameChar(tag[position]) {
+		position++
+	}
+	return position
+}
+
+// expectRuleEnd returns a syntax error unless the position is the end of the rule or the start of an option
+func expectRuleEnd(tag string, position int, after string) error {
+	if position == len(tag) || isTagSpace(tag[position]) || tag[position] == ';' {
+		return nil
+	}
+	return &TagSyntaxError{
+		Tag:     tag,
+		Column:  position + 1,
+		Message: "unexpected character " + strconv.QuoteRune(rune(tag[position])) + " in " + after,
+	}
+}
+
+// parseParams parses the comma separated parameters starting at the position until unquoted
+// whitespace or ";", returning them with the raw parameter text and the position after them
+func parseParams(tag string, position int) ([]string, string, int, error) {
+	var params []string
+	var raw, param strings.Builder
+	var quote byte
+	quoteStart := 0
+	for ; position < len(tag); position++ {
+		c := tag[position]
+		if quote == 0 && (isTagSpace(c) || c == ';') {
+			break
+		}
+
+		switch {
+		case c == '\\' && position+1 < len(tag) && isTagEscapable(tag[position+1]):
+			position++
+			raw.WriteByte(tag[position])
+			param.WriteByte(tag[position])
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote, quoteStart = c, position
+		case quote == 0 && c == ',':
+			raw.WriteByte(c)
+			params = append(params, param.String())
+			param.Reset()
+		default:
+			raw.WriteByte(c)
+			param.WriteByte(c)
+		}
+	}
+
+	if quote != 0 {
+		return nil, "", 0, &TagSyntaxError{Tag: tag, Column: quoteStart + 1, Message: "unterminated quote"}
+	}
+
+	return append(params, param.String()), raw.String(), position, nil
+}
+
+// isRawPattern determines if the parameters of the rule are an unquoted regular expression of
+// format, read as is (see Rule)
+func isRawPattern(name, params string) bool {
+	return name == "format" && strings.HasPrefix(params, "regexp:")
+}
+
+// parseRawParams reads the parameters starting at the position as is until whitespace, returning
+// them as a single parameter (commas are part of the expression) with the raw parameter text and
+// the position after them
+func parseRawParams(tag string, position int) ([]string, string, int) {
+	end := position
+	for end < len(tag) && !isTagSpace(tag[end]) {
+		end++
+	}
+	raw := tag[position:end]
+	return []string{raw}, raw, end
+}
+
+// isTagSpace determines if the character separates rules
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isTagNameChar determines if the character can be used in a validation name
+func isTagNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isTagEscapable determines if the character can be escaped with a backslash
+func isTagEscapable(c byte) bool {
+	return c == '\\' || c == '\'' || c == '"' || c == ',' || c == ' ' || c == ';'
+}
@@ -0,0 +1,304 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTag tests parsing validation tags into rules
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected []Rule
+	}{
+		{
+			name: "empty tag",
+			tag:  "",
+		},
+		{
+			name: "only whitespace",
+			tag:  "  \t ",
+		},
+		{
+			name: "single rule",
+			tag:  "min_length=5",
+			expected: []Rule{
+				{Name: "min_length", Params: []string{"5"}, Raw: "5", Source: "min_length=5", Column: 1},
+			},
+		},
+		{
+			name: "parameterless rules",
+			tag:  "required  dive",
+			expected: []Rule{
+				{Name: "required", Source: "required", Column: 1},
+				{Name: "dive", Source: "dive", Column: 11},
+			},
+		},
+		{
+			name: "empty parameter",
+			tag:  "max_length=",
+			expected: []Rule{
+				{Name: "max_length", Params: []string{""}, Source: "max_length=", Column: 1},
+			},
+		},
+		{
+			name: "comma separated parameters",
+			tag:  "required_if=Country,US",
+			expected: []Rule{
+				{Name: "required_if", Params: []string{"Country", "US"}, Raw: "Country,US", Source: "required_if=Country,US", Column: 1},
+			},
+		},
+		{
+			name: "unquoted regular expression keeps commas and equals in its parameter",
+			tag:  "format=regexp:^[a-z]{3,12}=$",
+			expected: []Rule{
+				{
+					Name:   "format",
+					Params: []string{"regexp:^[a-z]{3,12}=$"},
+					Raw:    "regexp:^[a-z]{3,12}=$",
+					Source: "format=regexp:^[a-z]{3,12}=$",
+					Column: 1,
+				},
+			},
+		},
+		{
+			name: "unquoted regular expression with a repetition range",
+			tag:  "format=regexp:^[0-9]{2,3}$ required",
+			expected: []Rule{
+				{
+					Name:   "format",
+					Params: []string{"regexp:^[0-9]{2,3}$"},
+					Raw:    "regexp:^[0-9]{2,3}$",
+					Source: "format=regexp:^[0-9]{2,3}$",
+					Column: 1,
+				},
+				{Name: "required", Source: "required", Column: 28},
+			},
+		},
+		{
+			name: "single quoted parameter with a space",
+			tag:  "format='regexp:^[A-Z][a-z]+ [A-Z][a-z]+$' min_length=3",
+			expected: []Rule{
+				{
+					Name:   "format",
+					Params: []string{"regexp:^[A-Z][a-z]+ [A-Z][a-z]+$"},
+					Raw:    "regexp:^[A-Z][a-z]+ [A-Z][a-z]+$",
+					Source: "format='regexp:^[A-Z][a-z]+ [A-Z][a-z]+$'",
+					Column: 1,
+				},
+				{Name: "min_length", Params: []string{"3"}, Raw: "3", Source: "min_length=3", Column: 43},
+			},
+		},
+		{
+			name: "double quoted parameters with commas",
+			tag:  `one_of="a,b",c`,
+			expected: []Rule{
+				{Name: "one_of", Params: []string{"a,b", "c"}, Raw: "a,b,c", Source: `one_of="a,b",c`, Column: 1},
+			},
+		},
+		{
+			name: "escapes",
+			tag:  `one_of=a\,b,it\'s,back\\slash,space\ here`,
+			expected: []Rule{
+				{
+					Name:   "one_of",
+					Params: []string{"a,b", "it's", `back\slash`, "space here"},
+					Raw:    `a,b,it's,back\slash,space here`,
+					Source: `one_of=a\,b,it\'s,back\\slash,space\ here`,
+					Column: 1,
+				},
+			},
+		},
+		{
+			name: "other backslashes are kept",
+			tag:  `format=regexp:^\d+\.\d+$`,
+			expected: []Rule{
+				{
+					Name:   "format",
+					Params: []string{`regexp:^\d+\.\d+$`},
+					Raw:    `regexp:^\d+\.\d+$`,
+					Source: `format=regexp:^\d+\.\d+$`,
+					Column: 1,
+				},
+			},
+		},
+		{
+			name: "legacy regular expression with quotes, semicolons and escapes",
+			tag:  `format=regexp:^[^';]+\\.\;$ min_length=2`,
+			expected: []Rule{
+				{
+					Name:   "format",
+					Params: []string{`regexp:^[^';]+\\.\;$`},
+					Raw:    `regexp:^[^';]+\\.\;$`,
+					Source: `format=regexp:^[^';]+\\.\;$`,
+					Column: 1,
+				},
+				{Name: "min_length", Params: []string{"2"}, Raw: "2", Source: "min_length=2", Column: 29},
+			},
+		},
+		{
+			name: "options",
+			tag:  "required;groups=create min_length=8;groups=create,update",
+			expected: []Rule{
+				{Name: "required", Options: map[string][]string{"groups": {"create"}}, Source: "required;groups=create", Column: 1},
+				{
+					Name:    "min_length",
+					Params:  []string{"8"},
+					Raw:     "8",
+					Options: map[string][]string{"groups": {"create", "update"}},
+					Source:  "min_length=8;groups=create,update",
+					Column:  24,
+				},
+			},
+		},
+		{
+			name: "option without parameters and quoted semicolon",
+			tag:  `format='regexp:^a;b$';strict`,
+			expected: []Rule{
+				{
+					Name:    "format",
+					Params:  []string{"regexp:^a;b$"},
+					Raw:     "regexp:^a;b$",
+					Options: map[string][]string{"strict": nil},
+					Source:  `format='regexp:^a;b$';strict`,
+					Column:  1,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseTag(tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+// TestLegacyRegexpTags tests regular expressions written before quotes and options are read as is
+func TestLegacyRegexpTags(t *testing.T) {
+	type Legacy struct {
+		Name    string `validation:"format=regexp:^[^']+$"`
+		Version string `validation:"format=regexp:^\\d+\\.\\d+$"`
+		Pair    string `validation:"format=regexp:^[a-z]+;[a-z]+$"`
+		Path    string `validation:"format=regexp:^[a-z]+\\\\[a-z]+$ max_length=9"`
+		Quoted  string `validation:"format=regexp:^\"[a-z]+\"$"`
+		Code    string `validation:"format=regexp:^[0-9]{2,3}$"`
+	}
+	m := NewMap()
+
+	ok, errs := m.IsValid(Legacy{Name: "John", Version: "1.20", Pair: "key;value", Path: `dir\file`, Quoted: `"a"`, Code: "123"})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	_, errs = m.IsValid(Legacy{Name: "O'Brien", Version: "1.x", Pair: "key=value", Path: "dir/file", Quoted: "a", Code: "1"})
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "does not match regexp format"},
+		{Key: "Version", Message: "does not match regexp format"},
+		{Key: "Pair", Message: "does not match regexp format"},
+		{Key: "Path", Message: "does not match regexp format"},
+		{Key: "Quoted", Message: "does not match regexp format"},
+		{Key: "Code", Message: "does not match regexp format"},
+	}, withoutDetails(errs))
+	assert.Equal(t, []string{"regexp:^[0-9]{2,3}$"}, errs[5].Params)
+}
+
+// TestParseTagErrors tests the syntax errors and their columns
+func TestParseTagErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		tag            string
+		expectedColumn int
+		expectedError  string
+	}{
+		{"missing name", "min=1 =5", 7, "syntax error at column 7: expected a validation name"},
+		{"invalid name character", "min_length'5'", 11, `syntax error at column 11: unexpected character '\'' in validation name`},
+		{"unterminated single quote", "format='regexp:^a", 8, "syntax error at column 8: unterminated quote"},
+		{"unterminated double quote", `min=1 one_of=a,"b c`, 16, "syntax error at column 16: unterminated quote"},
+		{"missing option name", "required;", 10, "syntax error at column 10: expected an option name"},
+		{"invalid option name character", "required;groups:create", 16, `syntax error at column 16: unexpected character ':' in option name`},
+		{"duplicate option", "required;groups=a;groups=b", 19, `syntax error at column 19: duplicate option "groups"`},
+		{"unterminated quote in option", "required;groups='a", 17, "syntax error at column 17: unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseTag(tt.tag)
+			require.Error(t, err)
+			assert.Nil(t, rules)
+			require.ErrorIs(t, err, ErrInvalidSpecification)
+
+			var syntaxErr *TagSyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.tag, syntaxErr.Tag)
+			assert.Equal(t, tt.expectedColumn, syntaxErr.Column)
+			assert.Equal(t, tt.expectedError, syntaxErr.Error())
+		})
+	}
+}
+
+// oneOfValidation is a test validation built from parsed parameters
+type oneOfValidation struct {
+	Validation
+
+	values []string
+}
+
+// Validate checks the value is one of the parameters
+func (o *oneOfValidation) Validate(value interface{}, _ reflect.Value) *ValidationError {
+	for _, allowed := range o.values {
+		if value == allowed {
+			return nil
+		}
+	}
+	return &ValidationError{Key: o.FieldName(), Message: "must be one of " + strings.Join(o.values, ", ")}
+}
+
+// TestMapAddRuleValidation tests builders that receive the parsed parameters
+func TestMapAddRuleValidation(t *testing.T) {
+	testMap := &Map{}
+	testMap.AddValidation("format", formatValidation)
+	testMap.AddRuleValidation("one_of", func(rule Rule, _ reflect.Kind) (Interface, error) {
+		return &oneOfValidation{values: rule.Params}, nil
+	})
+
+	type Person struct {
+		Name  string `validation:"format='regexp:^[A-Z][a-z]+ [A-Z][a-z]+$'"`
+		Title string `validation:"one_of='Dr.','Mr, Sr.',Ms"`
+	}
+
+	ok, errs := testMap.IsValid(Person{Name: "John Smith", Title: "Mr, Sr."})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	ok, errs = testMap.IsValid(Person{Name: "JohnSmith", Title: "Mr"})
+	assert.False(t, ok)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "Name does not match regexp format", errs[0].Error())
+	assert.Equal(t, "Title must be one of Dr., Mr, Sr., Ms", errs[1].Error())
+}
+
+// TestOneOfRuleValidation tests the built-in one_of rule receives the parsed parameters
+func TestOneOfRuleValidation(t *testing.T) {
+	type Person struct {
+		Title string `validation:"one_of='Dr.','Mr, Sr.',Ms"`
+	}
+	m := NewMap()
+
+	ok, errs := m.IsValid(Person{Title: "Mr, Sr."})
+	assert.True(t, ok)
+	assert.Empty(t, errs)
+
+	_, errs = m.IsValid(Person{Title: "Mr"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Title must be one of Dr., Mr, Sr., Ms", errs[0].Error())
+	assert.Equal(t, "one_of", errs[0].Code)
+	assert.Equal(t, []string{"Dr.", "Mr, Sr.", "Ms"}, errs[0].Params)
+	require.ErrorIs(t, ValidationErrors(errs), ErrEnumValueNotAllowed)
+}
@@ -0,0 +1,260 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// messageOption overrides the message of a rule, for all locales or for one with its locale as
+// a suffix, e.g. `validation:"min=18;message='must be an adult';message.es='debe ser mayor de edad'"`
+const messageOption = "message"
+
+// labelTag is the tag of the label of a field in messages, e.g. `label:"Date of birth"`
+const labelTag = "label"
+
+// Translator translates the messages of validation errors into a locale (e.g. "es" or "es-MX")
+type Translator interface {
+	// Translate returns the message of the error in the locale, false when there is none
+	Translate(err *ValidationError, locale string) (string, bool)
+}
+
+// Catalog holds the message templates of a locale by error code (see ValidationError.Code). The
+// template of a code and its first parameter (e.g. "format.email") is used before the template of
+// the code. Templates can use the placeholders {label} (the label of the field, or the key of the
+// error), {value}, {params} (all the parameters) and {0}, {1}... (each parameter).
+type Catalog map[string]string
+
+// CatalogTranslator translates messages with the catalogs of the locales. Regional locales
+// (e.g. "es-MX") fall back to the catalog of their language (e.g. "es") for the codes they lack.
+type CatalogTranslator struct {
+	// catalogs are the catalogs by locale, in lower case
+	catalogs map[string]Catalog
+
+	// lock protects the catalogs
+	lock sync.RWMutex
+}
+
+// NewTranslator creates a translator with the built-in English ("en") and Spanish ("es") catalogs
+func NewTranslator() *CatalogTranslator {
+	translator := &CatalogTranslator{catalogs: map[string]Catalog{}}
+	translator.AddCatalog("en", EnglishCatalog())
+	translator.AddCatalog("es", SpanishCatalog())
+	return translator
+}
+
+// AddCatalog adds the templates of the catalog to the locale, replacing the templates of the same codes
+func (t *CatalogTranslator) AddCatalog(locale string, catalog Catalog) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	locale = normalizeLocale(locale)
+	if t.catalogs[locale] == nil {
+		t.catalogs[locale] = Catalog{}
+	}
+	for code, template := range catalog {
+		t.catalogs[locale][code] = template
+	}
+}
+
+// Translate returns the message of the error from the template of its code in the locale
+func (t *CatalogTranslator) Translate(err *ValidationError, locale string) (string, bool) {
+	if len(err.Code) == 0 {
+		return "", false
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for _, candidate := range localeCandidates(locale) {
+		catalog := t.catalogs[candidate]
+		if len(err.Params) > 0 {
+			if template, ok := catalog[err.Code+"."+err.Params[0]]; ok {
+				return renderMessage(template, err), true
+			}
+		}
+		if template, ok := catalog[err.Code]; ok {
+			return renderMessage(template, err), true
+		}
+	}
+	return "", false
+}
+
+// EnglishCatalog returns the English templates of the built-in validations
+func EnglishCatalog() Catalog {
+	return Catalog{
+		"required":        "is required",
+		"required_if":     "is required when {0} is {1}",
+		"required_unless": "is required unless {0} is {1}",
+		"required_with":   "is required when {params} is present",
+		"excluded_with":   "must be empty when {params} is present",
+		"min_length":      "must be at least {0} characters",
+		"max_length":      "must be no more than {0} characters",
+		"min":             "must be greater than or equal to {0}",
+		"max":             "must be less than or equal to {0}",
+		"format":          "does not match regexp format",
+		"format.email":    "does not match email format",
+		"one_of":          "must be one of {params}",
+		"compare":         "is not the same as the compare field {0}",
+		"eq_field":        "must be equal to {0}",
+		"ne_field":        "must not be equal to {0}",
+		"gt_field":        "must be greater than {0}",
+		"gte_field":       "must be greater than or equal to {0}",
+		"lt_field":        "must be less than {0}",
+		"lte_field":       "must be less than or equal to {0}",
+		"type":            "is not of type {0}",
+		"unknown_field":   "refers to the unknown field {0}",
+		"not_comparable":  "cannot be compared to the field {0}",
+	}
+}
+
+// SpanishCatalog returns the Spanish templates of the built-in validations
+func SpanishCatalog() Catalog {
+	return Catalog{
+		"required":        "es obligatorio",
+		"required_if":     "es obligatorio cuando {0} es {1}",
+		"required_unless": "es obligatorio salvo que {0} sea {1}",
+		"required_with":   "es obligatorio cuando {params} está presente",
+		"excluded_with":   "debe estar vacío cuando {params} está presente",
+		"min_length":      "debe tener al menos {0} caracteres",
+		"max_length":      "debe tener como máximo {0} caracteres",
+		"min":             "debe ser mayor o igual que {0}",
+		"max":             "debe ser menor o igual que {0}",
+		"format":          "no tiene el formato esperado",
+		"format.email":    "no es un correo electrónico válido",
+		"one_of":          "debe ser uno de {params}",
+		"compare":         "no coincide con el campo {0}",
+		"eq_field":        "debe ser igual a {0}",
+		"ne_field":        "no debe ser igual a {0}",
+		"gt_field":        "debe ser mayor que {0}",
+		"gte_field":       "debe ser mayor o igual que {0}",
+		"lt_field":        "debe ser menor que {0}",
+		"lte_field":       "debe ser menor o igual que {0}",
+		"type":            "no es de tipo {0}",
+		"unknown_field":   "hace referencia al campo desconocido {0}",
+		"not_comparable":  "no se puede comparar con el campo {0}",
+	}
+}
+
+// Translate returns copies of the errors with their messages in the locale, from the message
+// options of their rules for the locale or else from the translator (nil for none). Errors with
+// a message option for all locales, or without a message in the locale, keep their message.
+func (v ValidationErrors) Translate(translator Translator, locale string) ValidationErrors {
+	if v == nil {
+		return nil
+	}
+
+	translated := make(ValidationErrors, len(v))
+	copy(translated, v)
+	for i := range translated {
+		err := &translated[i]
+		if template, ok := localeMessage(err.messages, locale); ok {
+			err.Message = renderMessage(template, err)
+		} else if _, custom := err.messages[""]; custom || translator == nil {
+			continue
+		} else if message, ok := translator.Translate(err, locale); ok {
+			err.Message = message
+		}
+	}
+	return translated
+}
+
+// messageOptions returns the message templates of the rule's message options by locale
+func messageOptions(rule *Rule) map[string]string {
+	var messages map[string]string
+	for option, params := range rule.Options {
+		if locale, ok := messageLocale(option); ok {
+			if messages == nil {
+				messages = make(map[string]string, len(rule.Options))
+			}
+			messages[locale] = strings.Join(params, ",")
+		}
+	}
+	return messages
+}
+
+// messageLocale returns the locale of a message option, "" for the message of all locales
+func messageLocale(option string) (string, bool) {
+	if option == messageOption {
+		return "", true
+	}
+	if locale := strings.TrimPrefix(option, messageOption+"."); len(locale) > 0 && len(locale) < len(option) {
+		return normalizeLocale(locale), true
+	}
+	return "", false
+}
+
+// localeMessage returns the message template of the locale, or of its language
+func localeMessage(messages map[string]string, locale string) (string, bool) {
+	for _, candidate := range localeCandidates(locale) {
+		if template, ok := messages[candidate]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// localeCandidates returns the locale and its language (e.g. "es-mx" and "es"), in lower case
+func localeCandidates(locale string) []string {
+	locale = normalizeLocale(locale)
+	if index := strings.IndexByte(locale, '-'); index > 0 {
+		return []string{locale, locale[:index]}
+	}
+	return []string{locale}
+}
+
+// normalizeLocale lower cases the locale and separates its parts with "-" (e.g. "es_MX" is "es-mx")
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// renderMessage replaces the placeholders of the template with the label (or key), value and
+// parameters of the error, keeping unknown placeholders as they are
+func renderMessage(template string, err *ValidationError) string {
+	var message strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		message.WriteString(template[:start])
+		if replacement, ok := err.placeholder(template[start+1 : end]); ok {
+			message.WriteString(replacement)
+		} else {
+			message.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	message.WriteString(template)
+	return message.String()
+}
+
+// placeholder returns the replacement of a placeholder of message templates
+func (v *ValidationError) placeholder(name string) (string, bool) {
+	switch name {
+	case "label":
+		if len(v.Label) > 0 {
+			return v.Label, true
+		}
+		return v.Key, true
+	case "value":
+		if v.Value == nil {
+			return "", true
+		}
+		return fmt.Sprint(v.Value), true
+	case "params":
+		return strings.Join(v.Params, ", "), true
+	}
+
+	if index, err := strconv.Atoi(name); err == nil && index >= 0 && index < len(v.Params) {
+		return v.Params[index], true
+	}
+	return "", false
+}
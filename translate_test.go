@@ -0,0 +1,299 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// translatedCustomer is a struct with labels and message options
+type translatedCustomer struct {
+	Name        string   `validation:"required" label:"Full name"`
+	Email       string   `validation:"format=email"`
+	DateOfBirth string   `validation:"required" label:"Date of birth"`
+	Age         int      `validation:"min=18;message='must be {0} or older';message.es='debe tener {0} años o más'"`
+	Nick        string   `validation:"min_length=3;message='is too short ({value})'"`
+	Tags        []string `validation:"dive max_length=3" label:"Tag"`
+}
+
+// TestCatalogTranslator tests translating errors with the built-in catalogs
+func TestCatalogTranslator(t *testing.T) {
+	_, errs := IsValid(translatedCustomer{Email: "invalid", Age: 12, Nick: "Al", Tags: []string{"long"}})
+	require.Len(t, errs, 6)
+
+	// Labels replace the keys in the messages
+	assert.Equal(t, "Full name is required", errs[0].Error())
+	assert.Equal(t, "DateOfBirth", errs[2].Key)
+	assert.Equal(t, "Date of birth is required", errs[2].Error())
+	assert.Equal(t, "Tag must be no more than 3 characters", errs[5].Error())
+
+	// Message options replace the messages, with their placeholders
+	assert.Equal(t, "Age must be 18 or older", errs[3].Error())
+	assert.Equal(t, "Nick is too short (Al)", errs[4].Error())
+
+	translator := NewTranslator()
+	messages := func(errs ValidationErrors) []string {
+		texts := make([]string, len(errs))
+		for i := range errs {
+			texts[i] = errs[i].Error()
+		}
+		return texts
+	}
+
+	assert.Equal(t, []string{
+		"Full name es obligatorio",
+		"Email no es un correo electrónico válido",
+		"Date of birth es obligatorio",
+		"Age debe tener 18 años o más",
+		"Nick is too short (Al)",
+		"Tag debe tener como máximo 3 caracteres",
+	}, messages(ValidationErrors(errs).Translate(translator, "es-MX")))
+
+	// The English catalog renders the parameters of the rules
+	assert.Equal(t, []string{
+		"Full name is required",
+		"Email does not match email format",
+		"Date of birth is required",
+		"Age must be 18 or older",
+		"Nick is too short (Al)",
+		"Tag must be no more than 3 characters",
+	}, messages(ValidationErrors(errs).Translate(translator, "en_US")))
+
+	// Locales without a catalog keep the messages, and the errors are not changed
+	assert.Equal(t, messages(errs), messages(ValidationErrors(errs).Translate(translator, "fr")))
+	assert.Equal(t, messages(errs), messages(ValidationErrors(errs).Translate(nil, "fr")))
+	assert.Nil(t, ValidationErrors(nil).Translate(translator, "es"))
+	assert.Equal(t, "Full name is required", errs[0].Error())
+
+	// Catalogs can be added, and regional catalogs fall back to their language for the other codes
+	translator.AddCatalog("pt", Catalog{"required": "é obrigatório", "format.email": "não é um e-mail válido"})
+	translator.AddCatalog("pt-BR", Catalog{"required": "é obrigatório (BR)"})
+	translated := ValidationErrors(errs).Translate(translator, "pt-BR")
+	assert.Equal(t, "Full name é obrigatório (BR)", translated[0].Error())
+	assert.Equal(t, "Email não é um e-mail válido", translated[1].Error())
+	assert.Equal(t, "Tag must be no more than 3 characters", translated[5].Error())
+
+	// Errors without a code are not translated
+	message, ok := translator.Translate(&ValidationError{Message: ErrNotStruct.Error()}, "es")
+	assert.False(t, ok)
+	assert.Empty(t, message)
+}
+
+// TestCatalogsComplete tests the built-in catalogs have the same codes
+func TestCatalogsComplete(t *testing.T) {
+	english, spanish := EnglishCatalog(), SpanishCatalog()
+	for code := range english {
+		assert.Contains(t, spanish, code)
+	}
+	assert.Len(t, spanish, len(english))
+
+	// The English templates render the messages of the validations
+	tests := []struct {
+		err      ValidationError
+		expected string
+	}{
+		{ValidationError{Code: "min_length", Params: []string{"5"}}, "must be at least 5 characters"},
+		{ValidationError{Code: "one_of", Params: []string{"a", "b"}}, "must be one of a, b"},
+		{ValidationError{Code: "format", Params: []string{"regexp:^[0-9]+$"}}, "does not match regexp format"},
+		{ValidationError{Code: "gte_field", Params: []string{"Start"}}, "must be greater than or equal to Start"},
+	}
+	translator := NewTranslator()
+	for _, test := range tests {
+		message, ok := translator.Translate(&test.err, "en")
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, message)
+	}
+}
+
+// catalogFailure returns the only error of validating the object with the map
+func catalogFailure(t *testing.T, m *Map, object interface{}) ValidationError {
+	_, errs := m.IsValid(object)
+	require.Len(t, errs, 1)
+	return errs[0]
+}
+
+// schemaFailure returns the only error of validating the object against the schema
+func schemaFailure(t *testing.T, properties map[string]*Schema, object map[string]interface{}) ValidationError {
+	schema, err := CompileSchema(&Schema{Properties: properties})
+	require.NoError(t, err)
+	_, errs := schema.Validate(object)
+	require.Len(t, errs, 1)
+	return errs[0]
+}
+
+// TestCatalogsRenderFailures tests every template of the built-in catalogs renders the errors of the
+// validations in both locales
+func TestCatalogsRenderFailures(t *testing.T) {
+	m := NewMap()
+	type Signup struct {
+		Name     string `validation:"required"`
+		Nick     string `validation:"min_length=3"`
+		Code     string `validation:"max_length=3"`
+		Age      int    `validation:"min=18"`
+		Score    int    `validation:"max=99"`
+		Zip      string `validation:"format=regexp:^[0-9]+$"`
+		Email    string `validation:"format=email"`
+		Status   string `validation:"one_of=pending,shipped"`
+		Password string
+		Confirm  string `validation:"compare=Password"`
+	}
+	valid := Signup{Name: "Al", Nick: "Ali", Age: 18, Zip: "1", Email: "al@domain.com", Status: "pending"}
+	signup := func(change func(*Signup)) Signup {
+		invalid := valid
+		change(&invalid)
+		return invalid
+	}
+	type Address struct {
+		Country string
+		State   string `validation:"required_if=Country,US"`
+		Zip     string `validation:"required_unless=Country,US"`
+		Street  string
+		City    string `validation:"required_with=Street"`
+		Box     string `validation:"excluded_with=Street"`
+	}
+	type Range struct {
+		Start int
+		Eq    int `validation:"eq_field=Start"`
+		Ne    int `validation:"ne_field=Start"`
+		Gt    int `validation:"gt_field=Start"`
+		Gte   int `validation:"gte_field=Start"`
+		Lt    int `validation:"lt_field=Start"`
+		Lte   int `validation:"lte_field=Start"`
+	}
+	ranged := func(change func(*Range)) Range {
+		invalid := Range{Start: 5, Eq: 5, Ne: 6, Gt: 6, Gte: 5, Lt: 4, Lte: 5}
+		change(&invalid)
+		return invalid
+	}
+	unknownField, err := NewMapRule[string](m, "Name", "compare=Other")
+	require.NoError(t, err)
+	_, unknownErrs := unknownField.Validate("a")
+	require.Len(t, unknownErrs, 1)
+	_, oneOfErrs := OneOf("Size", "S", "M").Validate("XL")
+	require.Len(t, oneOfErrs, 1)
+
+	tests := []struct {
+		err     ValidationError
+		english string
+		spanish string
+	}{
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Name = "" })),
+			"Name is required", "Name es obligatorio"},
+		{catalogFailure(t, m, Address{Country: "US", Zip: "1"}),
+			"State is required when Country is US", "State es obligatorio cuando Country es US"},
+		{catalogFailure(t, m, Address{Country: "CA", State: "ON"}),
+			"Zip is required unless Country is US", "Zip es obligatorio salvo que Country sea US"},
+		{catalogFailure(t, m, Address{Country: "US", State: "NY", Street: "Main", City: "", Box: ""}),
+			"City is required when Street is present", "City es obligatorio cuando Street está presente"},
+		{catalogFailure(t, m, Address{Country: "US", State: "NY", Box: "1", Street: "Main", City: "NYC"}),
+			"Box must be empty when Street is present", "Box debe estar vacío cuando Street está presente"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Nick = "Al" })),
+			"Nick must be at least 3 characters", "Nick debe tener al menos 3 caracteres"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Code = "ABCD" })),
+			"Code must be no more than 3 characters", "Code debe tener como máximo 3 caracteres"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Age = 12 })),
+			"Age must be greater than or equal to 18", "Age debe ser mayor o igual que 18"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Score = 100 })),
+			"Score must be less than or equal to 99", "Score debe ser menor o igual que 99"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Zip = "A1" })),
+			"Zip does not match regexp format", "Zip no tiene el formato esperado"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Email = "invalid" })),
+			"Email does not match email format", "Email no es un correo electrónico válido"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Status = "lost" })),
+			"Status must be one of pending, shipped", "Status debe ser uno de pending, shipped"},
+		{oneOfErrs[0],
+			"Size must be one of S, M", "Size debe ser uno de S, M"},
+		{catalogFailure(t, m, signup(func(s *Signup) { s.Password = "secret" })),
+			"Confirm is not the same as the compare field Password", "Confirm no coincide con el campo Password"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Eq = 4 })),
+			"Eq must be equal to Start", "Eq debe ser igual a Start"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Ne = 5 })),
+			"Ne must not be equal to Start", "Ne no debe ser igual a Start"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Gt = 5 })),
+			"Gt must be greater than Start", "Gt debe ser mayor que Start"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Gte = 4 })),
+			"Gte must be greater than or equal to Start", "Gte debe ser mayor o igual que Start"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Lt = 5 })),
+			"Lt must be less than Start", "Lt debe ser menor que Start"},
+		{catalogFailure(t, m, ranged(func(r *Range) { r.Lte = 6 })),
+			"Lte must be less than or equal to Start", "Lte debe ser menor o igual que Start"},
+		{schemaFailure(t, map[string]*Schema{"count": {Type: SchemaInteger}}, map[string]interface{}{"count": 1.5}),
+			"$.count is not of type integer", "$.count no es de tipo integer"},
+		{unknownErrs[0],
+			"Name refers to the unknown field Other", "Name hace referencia al campo desconocido Other"},
+		{schemaFailure(t, map[string]*Schema{"count": {Type: SchemaInteger, Rules: "gte_field=name"}},
+			map[string]interface{}{"count": 1, "name": "a"}),
+			"$.count cannot be compared to the field name", "$.count no se puede comparar con el campo name"},
+	}
+
+	// Every template of the catalogs is rendered by one of the errors
+	english, spanish := EnglishCatalog(), SpanishCatalog()
+	rendered := make(map[string]bool, len(english))
+	translator := NewTranslator()
+	for _, test := range tests {
+		code := test.err.Code
+		if len(test.err.Params) > 0 {
+			if _, ok := english[code+"."+test.err.Params[0]]; ok {
+				code += "." + test.err.Params[0]
+			}
+		}
+		rendered[code] = true
+		require.Contains(t, english, code)
+		require.Contains(t, spanish, code)
+
+		errs := ValidationErrors{test.err}
+		assert.Equal(t, test.english, errs.Translate(translator, "en")[0].Error(), code)
+		assert.Equal(t, test.spanish, errs.Translate(translator, "es")[0].Error(), code)
+	}
+	for code := range english {
+		assert.True(t, rendered[code], code)
+	}
+}
+
+// TestRenderMessage tests replacing the placeholders of message templates
+func TestRenderMessage(t *testing.T) {
+	err := &ValidationError{Key: "Items[0]", Params: []string{"1", "9"}, Value: 12}
+	tests := map[string]string{
+		"":                              "",
+		"is invalid":                    "is invalid",
+		"{label} is {value}":            "Items[0] is 12",
+		"must be between {0} and {1}":   "must be between 1 and 9",
+		"must be one of {params}":       "must be one of 1, 9",
+		"keeps {2}, {unknown} and {":    "keeps {2}, {unknown} and {",
+		"{{0}}":                         "{{0}}",
+		"ends with an open brace {0} {": "ends with an open brace 1 {",
+	}
+	for template, expected := range tests {
+		assert.Equal(t, expected, renderMessage(template, err), template)
+	}
+
+	err.Label, err.Value = "Item", nil
+	assert.Equal(t, "Item is ", renderMessage("{label} is {value}", err))
+}
+
+// TestMessageOptions tests the syntax of message options
+func TestMessageOptions(t *testing.T) {
+	type Empty struct {
+		Name string `validation:"required;message="`
+	}
+	type NoLocale struct {
+		Name string `validation:"required;message.='is missing'"`
+	}
+	type Quoted struct {
+		Name string `validation:"required;message='is missing, really';message.ES_mx=falta"`
+	}
+
+	_, errs := IsValid(Empty{})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, ValidationErrors(errs), ErrMessageRequired)
+
+	_, errs = IsValid(NoLocale{})
+	require.ErrorIs(t, ValidationErrors(errs), ErrUnknownOption)
+
+	_, errs = IsValid(Quoted{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "is missing, really", errs[0].Message)
+	assert.Equal(t, "falta", ValidationErrors(errs).Translate(nil, "es-MX")[0].Message)
+	assert.Equal(t, "is missing, really", ValidationErrors(errs).Translate(NewTranslator(), "es")[0].Message)
+}
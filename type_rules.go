@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypeRules declares the validations of a struct type's fields in code, for types that cannot
+// be given validation tags (e.g. generated or third-party types). The rules use the tag grammar
+// (see Rule) and are merged with the type's tags, running after them:
+//
+//	customer := &Customer{}
+//	err := validate.Rules(customer).
+//		Field("Email", "required", "format=email").
+//		FieldPtr(&customer.Age, "min=18").
+//		Register()
+//
+// Fields are found by name, or by a pointer to the field of the struct the rules were created from.
+type TypeRules struct {
+	// m is the map the rules are registered into
+	m *Map
+
+	// objectType is the struct type the rules are for
+	objectType reflect.Type
+
+	// object is the struct (or pointer to it) the rules were created from
+	object reflect.Value
+
+	// fields are the rules by field index
+	fields map[int]string
+
+	// err is the first error of the chain, returned by Register
+	err error
+}
+
+// Rules starts declaring the validations of the type of the object (a struct or a pointer to a
+// struct, which is needed to find fields by pointer), see TypeRules
+func (m *Map) Rules(object interface{}) *TypeRules {
+	rules := &TypeRules{m: m, object: reflect.ValueOf(object), fields: map[int]string{}}
+	if rules.object.IsValid() {
+		rules.objectType = indirectType(rules.object.Type())
+	}
+	if rules.objectType == nil || rules.objectType.Kind() != reflect.Struct {
+		rules.err = ErrNotStruct
+	}
+	return rules
+}
+
+// Field adds rules to the field with the given name
+func (r *TypeRules) Field(name string, rules ...string) *TypeRules {
+	if r.err != nil {
+		return r
+	}
+
+	field, ok := r.objectType.FieldByName(name)
+	if !ok || len(field.Index) != 1 {
+		r.err = fmt.Errorf("%w: %s", ErrUnknownField, name)
+		return r
+	}
+	return r.add(field.Index[0], rules)
+}
+
+// FieldPtr adds rules to the field the pointer points to, which must be a field of the
+// struct the rules were created from (e.g. &customer.Email)
+func (r *TypeRules) FieldPtr(fieldPointer interface{}, rules ...string) *TypeRules {
+	if r.err != nil {
+		return r
+	}
+
+	pointer := reflect.ValueOf(fieldPointer)
+	if r.object.Kind() != reflect.Pointer || r.object.IsNil() || pointer.Kind() != reflect.Pointer {
+		r.err = ErrNotFieldPointer
+		return r
+	}
+
+	// The field is found by its offset from the start of the struct
+	structStart, fieldStart := r.object.Pointer(), pointer.Pointer()
+	for i := 0; i < r.objectType.NumField(); i++ {
+		field := r.objectType.Field(i)
+		if structStart+field.Offset == fieldStart && field.Type == pointer.Type().Elem() {
+			return r.add(i, rules)
+		}
+	}
+
+	r.err = ErrNotFieldPointer
+	return r
+}
+
+// add appends the rules of the field at the index
+func (r *TypeRules) add(index int, rules []string) *TypeRules {
+	r.fields[index] = strings.TrimSpace(r.fields[index] + " " + strings.Join(rules, " "))
+	return r
+}
+
+// Register stores the rules, adding to any rules registered before for the type. The stored
+// plans of the type are rebuilt on next use. The first error of the chain is returned, or a
+// *CompileError when the rules (or the field's tag) cannot be compiled, in which case nothing is stored.
+func (r *TypeRules) Register() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.m.typeRulesLock.Lock()
+	defer r.m.typeRulesLock.Unlock()
+
+	// Merge with the rules registered before
+	previous := r.m.typeRules(r.objectType)
+	merged := make(map[int]string, len(previous)+len(r.fields))
+	for index, rules := range previous {
+		merged[index] = rules
+	}
+	for index, rules := range r.fields {
+		merged[index] = strings.TrimSpace(merged[index] + " " + rules)
+	}
+
+	// Check the rules compile (with the tags) before storing them
+	for index, rules := range merged {
+		field := r.objectType.Field(index)
+		check := newRuleSet(field.Type)
+		if err := r.m.buildFieldRules(check, r.objectType, field, index, field.Tag.Get("validation"), nil, nil); err != nil {
+			return err
+		}
+		if err := r.m.buildFieldRules(check, r.objectType, field, index, rules, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	// Store the rules and remove the plans built without them
+	r.m.fieldRules.Store(r.objectType, merged)
+	r.m.validator.Delete(r.objectType)
+	return nil
+}
+
+// typeRules gets the rules registered in code for the fields of a type by field index, nil if there are none
+func (m *Map) typeRules(objectType reflect.Type) map[int]string {
+	rules, ok := m.fieldRules.Load(objectType)
+	if !ok {
+		return nil
+	}
+	return rules.(map[int]string)
+}
+
+// Rules starts declaring the validations of the type of the object using DefaultMap, see Map.Rules
+func Rules(object interface{}) *TypeRules {
+	return DefaultMap.Rules(object)
+}
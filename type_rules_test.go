@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generatedCustomer is a struct that cannot be given validation tags
+type generatedCustomer struct {
+	Name    string
+	Email   string
+	Age     uint
+	Tags    []string
+	Limits  map[string]int `validation:"dive keys min_length=2 endkeys"`
+	Comment string         `validation:"max_length=10"`
+}
+
+// TestMapRules tests declaring validations in code, merged with the tags
+func TestMapRules(t *testing.T) {
+	m := &Map{}
+	m.AddValidation("min_length", minLengthValidation)
+	m.AddValidation("max_length", maxLengthValidation)
+	m.AddValidation("min", minValueValidation)
+	m.AddRuleValidation("required", requiredRuleValidation)
+
+	// Plans built before the rules are registered are replaced
+	ok, _ := m.IsValid(generatedCustomer{})
+	require.True(t, ok)
+
+	customer := &generatedCustomer{}
+	err := m.Rules(customer).
+		Field("Name", "required", "min_length=2").
+		FieldPtr(&customer.Age, "min=18").
+		FieldPtr(&customer.Tags, "omitempty dive min_length=3").
+		Field("Limits", "dive min=1").
+		Field("Comment", "min_length=2").
+		Register()
+	require.NoError(t, err)
+
+	// Rules can be added to by later registrations
+	require.NoError(t, m.Rules(generatedCustomer{}).Field("Email", "required").Register())
+
+	tests := []struct {
+		name         string
+		customer     generatedCustomer
+		expectedKeys []string
+	}{
+		{
+			name: "valid",
+			customer: generatedCustomer{
+				Name: "John", Email: "john@domain.com", Age: 18, Limits: map[string]int{"ab": 1}, Comment: "ok",
+			},
+		},
+		{
+			name:         "empty",
+			customer:     generatedCustomer{},
+			expectedKeys: []string{"Name", "Name", "Email", "Age", "Comment"},
+		},
+		{
+			name: "invalid values",
+			customer: generatedCustomer{
+				Name:    "J",
+				Email:   "john@domain.com",
+				Age:     17,
+				Tags:    []string{"abc", "ab"},
+				Limits:  map[string]int{"a": 0},
+				Comment: "a comment that is too long",
+			},
+			expectedKeys: []string{"Name", "Age", "Tags[1]", "Limits[a]", "Limits[a]", "Comment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := m.IsValid(tt.customer)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+// TestMapRulesErrors tests the errors of declaring validations in code
+func TestMapRulesErrors(t *testing.T) {
+	m := &Map{}
+	m.AddValidation("min_length", minLengthValidation)
+
+	customer := &generatedCustomer{}
+	other := &generatedCustomer{}
+
+	tests := []struct {
+		name          string
+		rules         *TypeRules
+		expectedError error
+	}{
+		{"not a struct", m.Rules(1).Field("Name", "min_length=1"), ErrNotStruct},
+		{"nil", m.Rules(nil), ErrNotStruct},
+		{"unknown field", m.Rules(customer).Field("Unknown", "min_length=1"), ErrUnknownField},
+		{"field pointer without a struct pointer", m.Rules(*customer).FieldPtr(&customer.Name, "min_length=1"), ErrNotFieldPointer},
+		{"field pointer of another struct", m.Rules(customer).FieldPtr(&other.Name, "min_length=1"), ErrNotFieldPointer},
+		{"field pointer of another type", m.Rules(customer).FieldPtr(customer, "min_length=1"), ErrNotFieldPointer},
+		{"not a pointer", m.Rules(customer).FieldPtr(customer.Name, "min_length=1"), ErrNotFieldPointer},
+		{"unknown validation", m.Rules(customer).Field("Name", "unknown=1"), ErrUnknownValidation},
+		{"invalid tag", m.Rules(customer).Field("Comment", "min_length=1"), ErrUnknownValidation},
+		{"keys must follow a dive of the same rules", m.Rules(customer).Field("Limits", "keys min_length=1 endkeys"), ErrInvalidKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.rules.Register(), tt.expectedError)
+		})
+	}
+
+	// Nothing was stored
+	assert.Nil(t, m.typeRules(reflect.TypeOf(generatedCustomer{})))
+}
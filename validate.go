@@ -4,8 +4,9 @@ Package validate (go-validate) provides validations for struct fields based on a
 package validate
 
 import (
-	"log"
+	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -32,6 +33,39 @@ type Interface interface {
 	Validate(value interface{}, obj reflect.Value) *ValidationError
 }
 
+// PresenceValidation is implemented by validations that test whether a value is present, such as
+// required. They receive the field value as declared (e.g. a nil pointer) and run for every value,
+// while all other validations receive the value pointers lead to and are skipped for nil pointers.
+type PresenceValidation interface {
+	Interface
+
+	// ValidatesPresence marks the validation as a presence validation
+	ValidatesPresence()
+}
+
+// ValueValidation is implemented by validations that read the value through reflection (e.g. with
+// value.Int() or value.String()), so IsValid does not box the value into an interface, which
+// allocates. ValidateValue receives the value Validate would receive, invalid for nil.
+type ValueValidation interface {
+	Interface
+
+	// ValidateValue determines if the value is valid. The value nil is returned if it is valid
+	ValidateValue(value reflect.Value, obj reflect.Value) *ValidationError
+}
+
+// StructValidator is implemented by structs with rules that cannot be written as tags, such as
+// invariants across several fields. IsValid calls ValidateStruct after the tag validations of the
+// struct (including nested structs and dived elements) and merges the errors, prefixing their keys
+// with the path to the struct. An error with an empty key is reported for the struct itself.
+// ValidateStruct must not call IsValid on the same struct, as that would call it again.
+type StructValidator interface {
+	// ValidateStruct returns the errors of the struct, nil if it is valid
+	ValidateStruct() []ValidationError
+}
+
+// structValidatorType is used to find the types implementing StructValidator
+var structValidatorType = reflect.TypeOf((*StructValidator)(nil)).Elem() //nolint:gochecknoglobals // Type used for struct validators
+
 // Validation is an implementation of an Interface and can be used to provide basic functionality
 // to a new validation type through an anonymous field
 type Validation struct {
@@ -73,111 +107,827 @@ func (v *Validation) Validate(_ interface{}, _ reflect.Value) *ValidationError {
 	}
 }
 
-// Map is an atomic validation map, and when two sets happen at the same time, the latest that started wins.
+// Map is an atomic validation map. The validations of each struct type are built once, on first
+// use, even when several goroutines validate the type at the same time.
 type Map struct {
-	validator               sync.Map // map[reflect.Type][]Interface
-	validationNameToBuilder sync.Map // map[string]func(string, reflect.Kind) (Interface, error)
+	validator               sync.Map // map[reflect.Type]*typePlans
+	validationNameToBuilder sync.Map // map[string]RuleBuilder
+	fieldRules              sync.Map // map[reflect.Type]map[int]string, see TypeRules
+	typeRulesLock           sync.Mutex
+	openAPIExtensions       sync.Map // map[string]OpenAPIExtension
+	fieldNames              FieldNameFunc
+	fieldNamesLock          sync.RWMutex
 }
 
+// RuleBuilder creates a validation from a parsed rule and the kind of the value it is applied to
+type RuleBuilder func(rule Rule, kind reflect.Kind) (Interface, error)
+
 // AddValidation registers the validation specified by a key to the known
 // validations. If more than one validation registers with the same key, the
 // last one will become the validation for that key.
+//
+// The builder receives the rule's parameters as a single string (Rule.Raw), use
+// AddRuleValidation to receive the parsed parameters.
 func (m *Map) AddValidation(key string, fn func(string, reflect.Kind) (Interface, error)) {
+	m.AddRuleValidation(key, func(rule Rule, kind reflect.Kind) (Interface, error) {
+		return fn(rule.Raw, kind)
+	})
+}
+
+// AddRuleValidation registers the validation specified by a key to the known
+// validations, with a builder that receives the parsed rule (see Rule). If more than
+// one validation registers with the same key, the last one will become the validation for that key.
+func (m *Map) AddRuleValidation(key string, fn RuleBuilder) {
 	m.validationNameToBuilder.Store(key, fn)
+	m.resetPlans()
 }
 
-// IsValid will either store the builder interfaces or run the IsValid based on the reflection object type
-func (m *Map) IsValid(object interface{}) (bool, []ValidationError) {
-	// Get the object's value and type
-	objectValue := reflect.ValueOf(object)
-	objectType := reflect.TypeOf(object)
+// Unregister removes the validation specified by a key from the known validations. Types
+// using it report an unknown validation from then on.
+func (m *Map) Unregister(key string) {
+	m.validationNameToBuilder.Delete(key)
+	m.resetPlans()
+}
+
+// List returns the names of the known validations, sorted
+func (m *Map) List() []string {
+	var names []string
+	m.validationNameToBuilder.Range(func(key, _ interface{}) bool {
+		names = append(names, key.(string))
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// Clone creates a map with the validations, type rules (see TypeRules), OpenAPI extensions and field
+// names (see SetFieldNames) of the map, which can be changed without changing the map. The plans are
+// built again on first use.
+func (m *Map) Clone() *Map {
+	clone := &Map{fieldNames: m.fieldNaming()}
+	copySyncMap(&clone.validationNameToBuilder, &m.validationNameToBuilder)
+	copySyncMap(&clone.openAPIExtensions, &m.openAPIExtensions)
+
+	// The rules of a type are replaced, never changed, when rules are registered
+	m.typeRulesLock.Lock()
+	copySyncMap(&clone.fieldRules, &m.fieldRules)
+	m.typeRulesLock.Unlock()
+
+	return clone
+}
+
+// copySyncMap stores the entries of the source in the destination
+func copySyncMap(destination, source *sync.Map) {
+	source.Range(func(key, value interface{}) bool {
+		destination.Store(key, value)
+		return true
+	})
+}
+
+// resetPlans removes the stored plans, which are rebuilt with the known validations on next use
+func (m *Map) resetPlans() {
+	m.validator.Range(func(key, _ interface{}) bool {
+		m.validator.Delete(key)
+		return true
+	})
+}
+
+// typePlans are the compiled plans of a struct type, one for each set of active groups
+type typePlans struct {
+	// ungrouped is the plan without active groups, looked up without allocating a key
+	ungrouped planEntry
+
+	// grouped are the plans of the sets of active groups (see groupsKey)
+	grouped sync.Map // map[string]*planEntry
+}
+
+// planEntry holds a plan built exactly once, including the plans of types without
+// validations and of types with invalid tags
+type planEntry struct {
+	once sync.Once
+	plan *structPlan
+}
+
+// structPlan is the compiled set of validations for a struct type
+type structPlan struct {
+	// fields are the fields holding validations or nested structs, in the order they are run
+	fields []fieldPlan
+
+	// err is set when the validation tags of the type could not be compiled
+	err *CompileError
 
-	// Get the validations
-	validations := m.get(objectType)
+	// structValidator is set when the type implements StructValidator, and pointerReceiver
+	// when only a pointer to the type does
+	structValidator bool
+	pointerReceiver bool
 
-	// If we are a pointer and not nil, run IsValid on it's interface
-	if objectValue.Kind() == reflect.Pointer && !objectValue.IsNil() {
-		return IsValid(objectValue.Elem().Interface())
+	// flat is set when no field is descended into (nested structs or collections), so the
+	// struct is validated without a path stack and cannot be part of a cycle
+	flat bool
+}
+
+// fieldPlan is the compiled set of validations for a single struct field
+type fieldPlan struct {
+	// index is the field index location
+	index int
+
+	// name is the field name
+	name string
+
+	// rules are the validations applied to the field value
+	rules ruleSet
+}
+
+// ruleSet is the set of validations applied to a value, and how to descend into it
+type ruleSet struct {
+	// validations are run against the value itself
+	validations []Interface
+
+	// rules are the parsed rules of the validations (nil for validations created in code, e.g. OneOf)
+	rules []Rule
+
+	// omitEmpty is set when the validations from omitEmptyFrom on (and descending into
+	// the value) are skipped for empty values
+	omitEmpty     bool
+	omitEmptyFrom int
+
+	// nested is set when the value is a struct (or pointer to a struct) to validate recursively
+	nested bool
+
+	// promoted is set for embedded structs, whose fields are reported without the field name
+	promoted bool
+
+	// elements are run against each element of a slice or array, or each value of a map (dive)
+	elements *ruleSet
+
+	// keys are run against each key of a map
+	keys *ruleSet
+
+	// label is the label of the field in messages (see labelTag), shared by its elements and keys
+	label string
+}
+
+// rule returns the parsed rule of the validation i, nil for validations created in code
+func (r *ruleSet) rule(i int) *Rule {
+	if i < len(r.rules) {
+		return &r.rules[i]
 	}
+	return nil
+}
 
-	// Do we have some validations?
-	if len(validations) == 0 {
-		validations = m.buildValidations(objectType)
-		m.set(objectType, validations)
+// Tags used to descend into collections, e.g. `validation:"dive keys min_length=2 endkeys min=0"`,
+// and to skip the rules that follow for empty values, e.g. `validation:"omitempty min_length=5"`
+const (
+	diveTag      = "dive"
+	keysTag      = "keys"
+	endKeysTag   = "endkeys"
+	omitEmptyTag = "omitempty"
+)
+
+// groupsOption limits a rule to the listed groups, e.g. `validation:"required;groups=create,admin"`
+const groupsOption = "groups"
+
+// redactOption leaves the offending value out of the errors of a rule, e.g. `validation:"min_length=12;redact"`
+const redactOption = "redact"
+
+// IsValid will either store the builder interfaces or run the IsValid based on the reflection object type.
+// Only the rules without groups are run, see IsValidGroups.
+func (m *Map) IsValid(object interface{}) (bool, []ValidationError) {
+	// Without groups there is no key to normalize, the plan is the ungrouped plan of the type
+	return m.validateObject(reflect.ValueOf(object), runMode{})
+}
+
+// IsValidGroups determines if an object is valid for the active groups, such as "create" or "update".
+// Rules limited to groups (e.g. `validation:"required;groups=create"`) only run when one of their
+// groups is active, and rules in no group always run.
+func (m *Map) IsValidGroups(object interface{}, groups ...string) (bool, []ValidationError) {
+	return m.validateObject(reflect.ValueOf(object), runMode{groups: groupsKey(groups)})
+}
+
+// validateObject runs the validations of a struct, or of the struct pointers lead to, in the mode
+func (m *Map) validateObject(objectValue reflect.Value, mode runMode) (bool, []ValidationError) {
+	// Follow pointers, the struct stays addressable for StructValidator pointer receivers
+	objectValue, absent := indirectValue(objectValue)
+
+	// Nothing to validate (nil)
+	if absent || !objectValue.IsValid() {
+		return false, []ValidationError{{Message: ErrNotStruct.Error(), Err: ErrNotStruct}}
 	}
 
-	// Loop and build errors
+	// Run the validations (including nested structs and collections), and when validating stops
+	// at a number of errors, the expensive validations after all the others. The root path has
+	// no stack until the struct descends into a value (see validateStruct).
+	var prefix valuePath
 	var errors []ValidationError
-	for _, validation := range validations {
-		field := objectValue.Field(validation.FieldIndex())
-		value := field.Interface()
-		if err := validation.Validate(value, objectValue); err != nil {
-			errors = append(errors, *err)
+	if mode.maxErrors > 0 {
+		mode.phase = cheapValidations
+		errors = m.validateStruct(errors, objectValue, prefix, mode)
+		if !mode.full(errors) {
+			mode.phase = expensiveValidations
+			errors = m.validateStruct(errors, objectValue, prefix, mode)
 		}
+	} else {
+		errors = m.validateStruct(errors, objectValue, prefix, mode)
 	}
 
 	// Return flag and errors
 	return len(errors) == 0, errors
 }
 
-// get will get the validator interface
-func (m *Map) get(k reflect.Type) []Interface {
-	v, ok := m.validator.Load(k)
+// validateStruct runs the validations of a struct value in the mode (see runMode), prefixing each
+// error key with the path to the struct (e.g. "Address.")
+func (m *Map) validateStruct(errors []ValidationError, objectValue reflect.Value, prefix valuePath, mode runMode,
+) []ValidationError {
+	// Get the validations, a type with invalid tags is reported (once) instead of being validated
+	plan := m.plan(objectValue.Type(), mode.groups)
+	if plan.err != nil {
+		if mode.phase == expensiveValidations {
+			return errors
+		}
+		return append(errors, plan.err.validationError(prefix.String()))
+	}
+
+	// Flat structs need no path stack, the others get one from the pool at the root of the object
+	if !plan.flat {
+		if prefix.stack == nil {
+			prefix = getPath("")
+			defer putPath(prefix)
+		}
+
+		// Structs already being validated, found again through a cycle of pointers, are not validated again
+		if ok, entered := prefix.enter(objectValue); !ok {
+			return errors
+		} else if entered {
+			defer prefix.leave()
+		}
+	}
+
+	// Loop and build errors
+	for i := range plan.fields {
+		if mode.full(errors) {
+			return errors
+		}
+		field := &plan.fields[i]
+		errors = m.validateValue(errors, &field.rules, objectValue.Field(field.index), objectValue,
+			prefix, prefix.fieldPath(field.name), mode)
+	}
+
+	// Merge the errors of the struct's own validation
+	if plan.structValidator && objectValue.CanInterface() && mode.phase != expensiveValidations && !mode.full(errors) {
+		errors = mode.limit(appendStructErrors(errors, objectValue, plan.pointerReceiver, prefix))
+	}
+
+	return errors
+}
+
+// appendStructErrors runs ValidateStruct on the struct value, or on a pointer to it for pointer
+// receivers, and appends the errors with their keys prefixed by the path to the struct
+func appendStructErrors(errors []ValidationError, objectValue reflect.Value, pointerReceiver bool,
+	prefix valuePath,
+) []ValidationError {
+	if pointerReceiver {
+		if objectValue.CanAddr() {
+			objectValue = objectValue.Addr()
+		} else {
+			pointer := reflect.New(objectValue.Type())
+			pointer.Elem().Set(objectValue)
+			objectValue = pointer
+		}
+	}
+
+	structErrors := objectValue.Interface().(StructValidator).ValidateStruct()
+	if len(structErrors) == 0 {
+		return errors
+	}
+
+	structPrefix := prefix.String()
+	for _, err := range structErrors {
+		if len(err.Key) == 0 {
+			err.Key = strings.TrimSuffix(structPrefix, ".")
+		} else {
+			err.Key = structPrefix + err.Key
+		}
+		errors = append(errors, err)
+	}
+	return errors
+}
+
+// validateValue runs a rule set against a value of the struct obj in the mode (see runMode). The
+// key is the full path of the value (e.g. "Emails[2]") and the prefix is the path of the struct holding it.
+func (m *Map) validateValue(errors []ValidationError, rules *ruleSet, value, obj reflect.Value,
+	prefix, key valuePath, mode runMode,
+) []ValidationError {
+	// Empty values skip the validations after omitempty, and have nothing to descend into
+	validations := rules.validations
+	omitted := rules.omitEmpty && isEmpty(value)
+	if omitted {
+		validations = validations[:rules.omitEmptyFrom]
+	}
+
+	// Nil pointers are absent, all other values are validated through their pointers
+	target, absent := indirectValue(value)
+	indirect := value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface
+
+	// Run the validations on the value itself, in passes over the cheap and then the expensive
+	// validations when validating stops early. A value that failed in an earlier phase has failed.
+	first, last := mode.phase, mode.phase
+	if mode.phase == allValidations && mode.stops() {
+		first, last = cheapValidations, expensiveValidations
+	}
+	failed := mode.phase == expensiveValidations && mode.fieldFailFast && len(validations) > 0 &&
+		hasKey(errors, key.String())
+	for phase := first; phase <= last && !failed; phase++ {
+		for j, validation := range validations {
+			if !phase.runs(validation) {
+				continue
+			}
+
+			// Presence validations see the value as declared, others are skipped for absent values
+			fieldValue := target
+			if indirect {
+				if _, ok := validation.(PresenceValidation); ok {
+					fieldValue = value
+				} else if absent {
+					continue
+				}
+			}
+
+			if err := runValidation(validation, fieldValue, obj); err != nil {
+				err.describe(rules.rule(j), validation.FieldName(), rules.label, fieldValue)
+
+				// Errors about the field itself get the full path, others (e.g. a compare field) are siblings
+				if err.Key == validation.FieldName() {
+					err.Key = key.String()
+				} else {
+					err.Key = prefix.String() + err.Key
+				}
+				errors = append(errors, *err)
+
+				if mode.full(errors) {
+					return errors
+				} else if mode.fieldFailFast {
+					failed = true
+					break
+				}
+			}
+		}
+	}
+
+	// Nothing to descend into
+	if omitted || absent || (!rules.nested && rules.elements == nil && rules.keys == nil) {
+		return errors
+	}
+	value = target
+
+	switch value.Kind() { //nolint:exhaustive // only structs and collections can be descended into
+	case reflect.Struct:
+		if !rules.nested {
+			break
+		}
+		// Embedded structs (and values without a key, see ValueRule) add nothing to the path
+		if rules.promoted || key.empty() {
+			return m.validateStruct(errors, value, prefix, mode)
+		}
+		return m.validateStruct(errors, value, key.push(pathSegment{kind: structSegment}), mode)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len() && !mode.full(errors); i++ {
+			element := key.push(pathSegment{index: i, kind: indexSegment})
+			errors = m.validateValue(errors, rules.elements, value.Index(i), obj, prefix, element, mode)
+		}
+	case reflect.Map:
+		// Maps already being validated, found again through a cycle, are not validated again
+		ok, entered := key.enter(value)
+		if !ok {
+			break
+		} else if entered {
+			defer key.leave()
+		}
+
+		// Sort the keys so errors are reported in a deterministic order
+		mapKeys := value.MapKeys()
+		names := make([]string, len(mapKeys))
+		for i, mapKey := range mapKeys {
+			names[i] = fmt.Sprint(mapKey.Interface())
+		}
+		sort.Sort(mapKeySorter{keys: mapKeys, names: names})
+
+		for i, mapKey := range mapKeys {
+			element := key.push(pathSegment{name: names[i], kind: mapKeySegment})
+			if rules.keys != nil && !mode.full(errors) {
+				errors = m.validateValue(errors, rules.keys, mapKey, obj, prefix, element, mode)
+			}
+			if mode.full(errors) {
+				break
+			}
+			errors = m.validateValue(errors, rules.elements, value.MapIndex(mapKey), obj, prefix, element, mode)
+		}
+	}
+
+	return errors
+}
+
+// runValidation runs a validation on a value (invalid for nil), through ValidateValue when the
+// validation implements ValueValidation, so the value is only boxed for the other validations
+func runValidation(validation Interface, value, obj reflect.Value) *ValidationError {
+	// Validations see the value an interface holds, as when it is boxed
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if typed, ok := validation.(ValueValidation); ok {
+		return typed.ValidateValue(value, obj)
+	}
+
+	var boxed interface{}
+	if value.IsValid() {
+		boxed = value.Interface()
+	}
+	return validation.Validate(boxed, obj)
+}
+
+// indirectValue follows pointers (and interfaces) to the value they hold, reporting
+// whether a nil was found along the way
+func indirectValue(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, true
+		}
+		value = value.Elem()
+	}
+	return value, false
+}
+
+// mapKeySorter sorts map keys by their printed names
+type mapKeySorter struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (s mapKeySorter) Len() int           { return len(s.keys) }
+func (s mapKeySorter) Less(i, j int) bool { return s.names[i] < s.names[j] }
+func (s mapKeySorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+}
+
+// groupsKey normalizes the active groups into the key their plans are stored under,
+// so the same groups in any order (or repeated) share a plan
+func groupsKey(groups []string) string {
+	switch len(groups) {
+	case 0:
+		return ""
+	case 1:
+		return groups[0]
+	}
+
+	sorted := make([]string, len(groups))
+	copy(sorted, groups)
+	sort.Strings(sorted)
+
+	unique := sorted[:1]
+	for _, group := range sorted[1:] {
+		if group != unique[len(unique)-1] {
+			unique = append(unique, group)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+// plan gets the validations of a type for the active groups, building and storing them on first use
+func (m *Map) plan(objectType reflect.Type, groups string) *structPlan {
+	entry := m.planEntry(objectType, groups)
+	entry.once.Do(func() {
+		var active []string
+		if len(groups) > 0 {
+			active = strings.Split(groups, ",")
+		}
+
+		plan, err := m.buildValidations(objectType, active)
+		if err != nil {
+			plan = &structPlan{err: err}
+		}
+		entry.plan = plan
+	})
+	return entry.plan
+}
+
+// planEntry gets the entry of the plan of a type for the active groups, storing an empty entry on first use.
+// Without active groups (as for IsValid) the entry is found with a single lookup of the type.
+func (m *Map) planEntry(objectType reflect.Type, groups string) *planEntry {
+	plans, ok := m.validator.Load(objectType)
+	if !ok {
+		plans, _ = m.validator.LoadOrStore(objectType, &typePlans{})
+	}
+	if len(groups) == 0 {
+		return &plans.(*typePlans).ungrouped
+	}
+
+	entry, ok := plans.(*typePlans).grouped.Load(groups)
 	if !ok {
-		return []Interface{}
+		entry, _ = plans.(*typePlans).grouped.LoadOrStore(groups, &planEntry{})
+	}
+	return entry.(*planEntry)
+}
+
+// Compile builds and stores the validations of the type and of every struct it validates
+// recursively (nested structs and dived elements), so tag errors can be found at startup
+// rather than on the first call to IsValid. A *CompileError is returned for the first invalid tag.
+func (m *Map) Compile(objectType reflect.Type) error {
+	if objectType == nil {
+		return &CompileError{Err: ErrNotStruct}
+	}
+	return m.compile(indirectType(objectType), map[reflect.Type]bool{})
+}
+
+// Register compiles the types of the given values (structs or pointers to structs), see Compile
+func (m *Map) Register(values ...interface{}) error {
+	for _, value := range values {
+		if err := m.Compile(reflect.TypeOf(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile compiles a struct type and the types it descends into, seen guards against recursive types
+func (m *Map) compile(objectType reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[objectType] {
+		return nil
+	}
+	seen[objectType] = true
+
+	plan := m.plan(objectType, "")
+	if plan.err != nil {
+		return plan.err
 	}
-	return v.([]Interface)
+
+	for i := range plan.fields {
+		field := &plan.fields[i]
+		if err := m.compileRules(&field.rules, objectType.Field(field.index).Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// set will store the validator interface
-func (m *Map) set(k reflect.Type, v []Interface) {
-	m.validator.Store(k, v)
+// compileRules compiles the struct types a rule set descends into
+func (m *Map) compileRules(rules *ruleSet, valueType reflect.Type, seen map[reflect.Type]bool) error {
+	valueType = indirectType(valueType)
+	if rules.nested {
+		if err := m.compile(valueType, seen); err != nil {
+			return err
+		}
+	}
+	if rules.keys != nil {
+		if err := m.compileRules(rules.keys, valueType.Key(), seen); err != nil {
+			return err
+		}
+	}
+	if rules.elements != nil {
+		return m.compileRules(rules.elements, valueType.Elem(), seen)
+	}
+	return nil
 }
 
-// buildValidations constructs validations for a given object type
-func (m *Map) buildValidations(objectType reflect.Type) []Interface {
-	var validations []Interface
-	var err error
+// buildValidations constructs validations for a given object type, keeping the rules in no
+// group or in one of the active groups. Every rule is built, so invalid tags are found for any groups.
+func (m *Map) buildValidations(objectType reflect.Type, groups []string) (*structPlan, *CompileError) {
+	if objectType.Kind() != reflect.Struct {
+		return nil, &CompileError{Struct: objectType.String(), Err: ErrNotStruct}
+	}
+
+	plan := &structPlan{}
+
+	// Structs validating themselves, through a value or a pointer receiver
+	if objectType.Implements(structValidatorType) {
+		plan.structValidator = true
+	} else if reflect.PointerTo(objectType).Implements(structValidatorType) {
+		plan.structValidator, plan.pointerReceiver = true, true
+	}
 
-	// Loop the fields and decrement through the loop
-	for i := objectType.NumField() - 1; i >= 0; i-- {
+	// Rules declared in code are merged with the tags, and the fields named in the errors
+	typeRules := m.typeRules(objectType)
+	names := m.fieldNaming()
+
+	// Loop the fields in declaration order, so errors are reported in that order
+	for i := 0; i < objectType.NumField(); i++ {
 		field := objectType.Field(i)
 		validationTag := field.Tag.Get("validation")
+		codeRules := typeRules[i]
+
+		// Exported struct fields (or pointers to structs) are validated recursively
+		nested := field.IsExported() && isStructType(field.Type)
 
-		// Do we have a validation tag?
-		if len(validationTag) == 0 {
+		// Do we have a validation tag, rules in code or a nested struct?
+		if len(validationTag) == 0 && len(codeRules) == 0 && !nested {
 			continue
 		}
 
-		validationComponent := strings.Split(validationTag, " ")
+		// Embedded structs are promoted, unless the naming gives them a name
+		name := ""
+		if names != nil {
+			name = names(field)
+		}
+		fieldRules := fieldPlan{
+			index: i,
+			name:  field.Name,
+			rules: ruleSet{
+				nested: nested, promoted: nested && field.Anonymous && len(name) == 0, label: field.Tag.Get(labelTag),
+			},
+		}
+		if len(name) > 0 {
+			fieldRules.name = name
+		}
+		if err := m.buildFieldRules(&fieldRules.rules, objectType, field, i, validationTag, groups, names); err != nil {
+			return nil, err
+		}
+		if err := m.buildFieldRules(&fieldRules.rules, objectType, field, i, codeRules, groups, names); err != nil {
+			return nil, err
+		}
+
+		plan.fields = append(plan.fields, fieldRules)
+	}
+
+	plan.flat = true
+	for i := range plan.fields {
+		if rules := &plan.fields[i].rules; rules.nested || rules.elements != nil || rules.keys != nil {
+			plan.flat = false
+		}
+	}
+
+	return plan, nil
+}
+
+// buildFieldRules adds the validations of the field's tag to its rule set, diving into
+// element and key rule sets as the tag requires. The validations mentioning other fields name
+// them with names (nil for their Go names).
+func (m *Map) buildFieldRules(fieldRules *ruleSet, objectType reflect.Type, field reflect.StructField,
+	index int, validationTag string, groups []string, names FieldNameFunc,
+) *CompileError {
+	parsedRules, err := ParseTag(validationTag)
+	if err != nil {
+		return newCompileError(objectType, field, validationTag, err)
+	}
+
+	// rules is the set the next validation is added to, and ruleType the type it validates
+	rules, ruleType := fieldRules, field.Type
+
+	// collection is the set holding the current dive, and collectionType the type being dived into
+	var collection *ruleSet
+	var collectionType reflect.Type
+	inKeys, afterDive := false, false
+
+	// Loop each rule, modifiers never have parameters or options
+	for i, rule := range parsedRules {
+		afterDive = i > 0 && parsedRules[i-1].Source == diveTag
 
-		// Loop each validation component
-		for _, validationSpec := range validationComponent {
-			component := strings.Split(validationSpec, "=")
-			if len(component) != 2 {
-				log.Fatalln("invalid validation specification:", objectType.Name(), field.Name, validationSpec)
+		modifier := ""
+		if rule.Params == nil {
+			modifier = rule.Name
+		}
+		if isModifier(modifier) && rule.Options != nil {
+			return newCompileError(objectType, field, rule.Source, ErrModifierOptions)
+		}
+
+		switch modifier {
+		case diveTag:
+			elementType := indirectType(ruleType)
+			if inKeys || !isCollectionKind(elementType.Kind()) {
+				return newCompileError(objectType, field, rule.Source, ErrInvalidDive)
+			}
+			// The elements may already have rules from the field's other rules (see TypeRules)
+			collection, collectionType = rules, elementType
+			if collection.elements == nil {
+				collection.elements = newRuleSet(elementType.Elem())
+				collection.elements.label = fieldRules.label
 			}
+			rules, ruleType = collection.elements, elementType.Elem()
+			continue
+		case keysTag:
+			// Keys must come directly after diving into a map
+			if !afterDive || collectionType.Kind() != reflect.Map {
+				return newCompileError(objectType, field, rule.Source, ErrInvalidKeys)
+			}
+			if collection.keys == nil {
+				collection.keys = newRuleSet(collectionType.Key())
+				collection.keys.label = fieldRules.label
+			}
+			rules, ruleType = collection.keys, collectionType.Key()
+			inKeys = true
+			continue
+		case endKeysTag:
+			if !inKeys {
+				return newCompileError(objectType, field, rule.Source, ErrMissingKeys)
+			}
+			rules, ruleType = collection.elements, collectionType.Elem()
+			inKeys = false
+			continue
+		case omitEmptyTag:
+			// The first omitempty of the set wins when the field has several sets of rules
+			if !rules.omitEmpty {
+				rules.omitEmpty, rules.omitEmptyFrom = true, len(rules.validations)
+			}
+			continue
+		}
 
-			// Create the validation
-			var validation Interface
-			if builder, ok := m.validationNameToBuilder.Load(component[0]); ok && builder != nil {
-				fn := builder.(func(string, reflect.Kind) (Interface, error))
-				validation, err = fn(component[1], field.Type.Kind())
-				if err != nil {
-					log.Fatalln("error creating validation:", objectType.Name(), field.Name, validationSpec, err)
-				}
-			} else {
-				log.Fatalln("unknown validation named:", component[0])
+		// Check the options, and whether the rule is in the active groups
+		active, err := ruleInGroups(rule, groups)
+		if err != nil {
+			return newCompileError(objectType, field, rule.Source, err)
+		}
+
+		// Create the validation
+		builder, ok := m.validationNameToBuilder.Load(rule.Name)
+		if !ok || builder == nil {
+			return newCompileError(objectType, field, rule.Source,
+				fmt.Errorf("%w: %s", ErrUnknownValidation, rule.Name))
+		}
+		validation, err := builder.(RuleBuilder)(rule, indirectType(ruleType).Kind())
+		if err != nil {
+			return newCompileError(objectType, field, rule.Source, err)
+		}
+
+		// Rules of other groups are only built to check them
+		if !active {
+			continue
+		}
+
+		// Store the other properties and append to validations
+		validation.SetFieldName(field.Name)
+		validation.SetFieldIndex(index)
+		if namer, ok := validation.(fieldsNamer); ok && names != nil {
+			namer.setFieldNames(names)
+		}
+		rules.validations = append(rules.validations, validation)
+		rules.rules = append(rules.rules, rule)
+	}
+
+	if inKeys {
+		return newCompileError(objectType, field, validationTag, ErrMissingEndKeys)
+	}
+
+	return nil
+}
+
+// ruleInGroups checks the options of the rule, and determines if it runs for the active groups
+func ruleInGroups(rule Rule, groups []string) (bool, error) {
+	for option, params := range rule.Options {
+		if _, isMessage := messageLocale(option); isMessage {
+			if len(strings.Join(params, ",")) == 0 {
+				return false, ErrMessageRequired
 			}
+		} else if option != groupsOption && option != redactOption {
+			return false, fmt.Errorf("%w: %s", ErrUnknownOption, option)
+		}
+	}
 
-			// Store the other properties and append to validations
-			validation.SetFieldName(field.Name)
-			validation.SetFieldIndex(i)
-			validations = append(validations, validation)
+	ruleGroups, ok := rule.Options[groupsOption]
+	if !ok {
+		return true, nil
+	} else if len(ruleGroups) == 0 {
+		return false, ErrGroupNameRequired
+	}
+
+	active := false
+	for _, ruleGroup := range ruleGroups {
+		if len(ruleGroup) == 0 {
+			return false, ErrGroupNameRequired
+		}
+		for _, group := range groups {
+			if ruleGroup == group {
+				active = true
+			}
 		}
 	}
+	return active, nil
+}
+
+// isModifier determines if the name is one of the modifiers (dive, keys, endkeys and omitempty)
+func isModifier(name string) bool {
+	return name == diveTag || name == keysTag || name == endKeysTag || name == omitEmptyTag
+}
 
-	return validations
+// newRuleSet creates an empty rule set for values of the given type
+func newRuleSet(t reflect.Type) *ruleSet {
+	return &ruleSet{nested: isStructType(t)}
+}
+
+// indirectType removes any levels of pointers from the type
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isStructType determines if the type is a struct or a pointer (at any depth) to a struct
+func isStructType(t reflect.Type) bool {
+	return indirectType(t).Kind() == reflect.Struct
+}
+
+// isCollectionKind determines if the kind holds elements that can be dived into
+func isCollectionKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
 }
 
 // AddValidation registers the validation specified by a key to the known
@@ -188,7 +938,38 @@ func AddValidation(key string, fn func(string, reflect.Kind) (Interface, error))
 	DefaultMap.AddValidation(key, fn)
 }
 
+// Compile builds and stores the validations of the type using DefaultMap, see Map.Compile
+func Compile(objectType reflect.Type) error {
+	return DefaultMap.Compile(objectType)
+}
+
+// Register compiles the types of the given values using DefaultMap, see Map.Register
+func Register(values ...interface{}) error {
+	return DefaultMap.Register(values...)
+}
+
+// AddRuleValidation registers the validation specified by a key to the known
+// validations, with a builder that receives the parsed rule using DefaultMap.
+func AddRuleValidation(key string, fn RuleBuilder) {
+	DefaultMap.AddRuleValidation(key, fn)
+}
+
+// Unregister removes the validation specified by a key from the known validations of DefaultMap, see Map.Unregister
+func Unregister(key string) {
+	DefaultMap.Unregister(key)
+}
+
+// List returns the names of the known validations of DefaultMap, sorted
+func List() []string {
+	return DefaultMap.List()
+}
+
 // IsValid determines if an object is valid based on its validation tags using DefaultMap.
 func IsValid(object interface{}) (bool, []ValidationError) {
 	return DefaultMap.IsValid(object)
 }
+
+// IsValidGroups determines if an object is valid for the active groups using DefaultMap, see Map.IsValidGroups
+func IsValidGroups(object interface{}, groups ...string) (bool, []ValidationError) {
+	return DefaultMap.IsValidGroups(object, groups...)
+}
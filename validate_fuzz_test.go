@@ -8,15 +8,26 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func FuzzBuildValidations(f *testing.F) {
-	// We need to create struct types dynamically, but Go doesn't allow runtime struct creation easily
-	// Instead, we'll test the validation tag parsing logic indirectly through IsValid
+// compileFuzzedTag compiles the tag for values of type T in the map, and validates the value when
+// it compiles. Tags that cannot be compiled must be reported as compile errors.
+func compileFuzzedTag[T any](t *testing.T, m *Map, validationTag string, value T) {
+	rule, err := NewMapRule[T](m, "Value", validationTag)
+	if err != nil {
+		var compileErr *CompileError
+		require.ErrorAs(t, err, &compileErr, "tag %q", validationTag)
+		return
+	}
+	rule.Validate(value)
+}
 
+func FuzzBuildValidations(f *testing.F) {
 	// Seed corpus with various validation tag combinations
 	f.Add("max_length=10")
 	f.Add("min_length=5")
 	f.Add("format=email")
 	f.Add("format=regexp:^[a-z]+$")
+	f.Add("format=regexp:^[^';]+$;strict")
+	f.Add("format='regexp:^a b$';groups=create")
 	f.Add("min=0 max=100")
 	f.Add("max_length=50 format=email")
 	f.Add("compare=OtherField")
@@ -28,32 +39,30 @@ func FuzzBuildValidations(f *testing.F) {
 	f.Add("max_length=abc") // Invalid number
 	f.Add("format=invalid_format")
 	f.Add("min=-1 max=abc")
+	f.Add("required omitempty one_of=a,'b c';message='must be {params}'")
+	f.Add("dive keys min_length=1 endkeys required_if=Other,x")
+	f.Add(`one_of=a\,b,"c`)                      // Unterminated quote
 	f.Add(strings.Repeat("max_length=10 ", 100)) // Very long tag
 
 	f.Fuzz(func(t *testing.T, validationTag string) {
-		defer func() {
-			if r := recover(); r != nil {
-				// Some panics are expected for invalid validation tags
-				// The system uses log.Fatalln for invalid specs, which we can't easily test
-				// So we allow panics but log them
-				t.Logf("buildValidations panicked with tag %q: %v", validationTag, r)
-			}
-		}()
-
-		// We can't easily create dynamic structs, so we test the validation registration instead
-		// Create a test validation map
-		testMap := &Map{}
-
-		// Register basic validations to test with
-		testMap.AddValidation("max_length", maxLengthValidation)
-		testMap.AddValidation("min_length", minLengthValidation)
-		testMap.AddValidation("format", formatValidation)
-		testMap.AddValidation("compare", stringEqualsStringValidation)
-		testMap.AddValidation("min", minValueValidation)
-		testMap.AddValidation("max", maxValueValidation)
+		// Malformed tags are syntax errors, and the rules of the others are read from the tag
+		rules, err := ParseTag(validationTag)
+		if err != nil {
+			var syntaxErr *TagSyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Nil(t, rules)
+		}
+		for _, rule := range rules {
+			require.Contains(t, validationTag, rule.Source)
+		}
 
-		// Test that adding validations doesn't panic
-		_ = testMap
+		// Compile the tag with the built-in validations for values of several kinds
+		m := NewMap()
+		compileFuzzedTag(t, m, validationTag, "value")
+		compileFuzzedTag(t, m, validationTag, -1)
+		compileFuzzedTag(t, m, validationTag, 1.5)
+		compileFuzzedTag(t, m, validationTag, []string{"a", ""})
+		compileFuzzedTag(t, m, validationTag, map[string]int{"a": 1})
 	})
 }
 
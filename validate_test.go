@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,32 +28,73 @@ var invalidNumericTypes = []reflect.Kind{ //nolint:gochecknoglobals // Test data
 	reflect.UnsafePointer,
 }
 
-// TestValidationMap_Atomicity
-func TestValidationMap_Atomicity(_ *testing.T) {
+// TestValidationMap_Atomicity tests the validations of a type are built once under concurrent first use
+func TestValidationMap_Atomicity(t *testing.T) {
+	type atomicModel struct {
+		Value string `validation:"counted"`
+	}
+
 	vm := Map{}
-	// typ := reflect.TypeOf(vm)
-	typ := reflect.TypeOf(&vm) // todo: go vet: call of reflect.TypeOf copies lock value: govalidation.Map contains sync.Map contains sync.Mutex
-	wg1 := sync.WaitGroup{}
-	wg1.Add(1)
-	wg2 := sync.WaitGroup{}
-	wg2.Add(2)
-	count := 10000
-	go func() {
-		wg1.Wait()
-		for i := 0; i < count; i++ {
-			vm.set(typ, nil)
-		}
-		wg2.Done()
-	}()
-	go func() {
-		wg1.Wait()
-		for i := 0; i < count; i++ {
-			vm.get(typ)
+	var builds int32
+	vm.AddValidation("counted", func(string, reflect.Kind) (Interface, error) {
+		atomic.AddInt32(&builds, 1)
+		return &requiredValidation{}, nil
+	})
+
+	start := sync.WaitGroup{}
+	start.Add(1)
+	done := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			start.Wait()
+			for j := 0; j < 100; j++ {
+				_, _ = vm.IsValid(atomicModel{})
+			}
+		}()
+	}
+	start.Done() // start !
+	done.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&builds))
+}
+
+// TestValidationMap_NegativeEntries tests the plans of types without validations are stored
+func TestValidationMap_NegativeEntries(t *testing.T) {
+	type untagged struct {
+		Name string
+	}
+
+	vm := Map{}
+	ok, errs := vm.IsValid(untagged{})
+	require.True(t, ok)
+	require.Empty(t, errs)
+
+	plan := storedPlan(&vm, reflect.TypeOf(untagged{}), "")
+	require.NotNil(t, plan)
+	assert.Empty(t, plan.fields)
+
+	_, _ = vm.IsValid(&untagged{})
+	assert.Same(t, plan, storedPlan(&vm, reflect.TypeOf(untagged{}), ""))
+}
+
+// storedPlan gets the plan stored for a type and groups, nil if it was not built
+func storedPlan(m *Map, objectType reflect.Type, groups string) *structPlan {
+	plans, ok := m.validator.Load(objectType)
+	if !ok {
+		return nil
+	}
+
+	entry := &plans.(*typePlans).ungrouped
+	if len(groups) > 0 {
+		grouped, found := plans.(*typePlans).grouped.Load(groups)
+		if !found {
+			return nil
 		}
-		wg2.Done()
-	}()
-	wg1.Done() // start !
-	wg2.Wait()
+		entry = grouped.(*planEntry)
+	}
+	return entry.plan
 }
 
 // TestValidationSetFieldName test setting and getting field name
@@ -170,6 +213,970 @@ func TestValidationValidateFunc(t *testing.T) {
 	assert.Equal(t, expectedMessage, result.Message)
 }
 
+// TestMapIsValidNestedStructs tests validating fields of nested structs with dotted paths
+func TestMapIsValidNestedStructs(t *testing.T) {
+	type Address struct {
+		PostalCode string `validation:"min_length=5"`
+	}
+
+	type Base struct {
+		ID int64 `validation:"min=1"`
+	}
+
+	type Customer struct {
+		Base
+		Name     string `validation:"min_length=2"`
+		Address  Address
+		Billing  *Address
+		internal Address //nolint:unused // unexported fields are skipped
+	}
+
+	tests := []struct {
+		name         string
+		customer     Customer
+		expectedKeys []string
+	}{
+		{
+			name: "all valid",
+			customer: Customer{
+				Base:    Base{ID: 1},
+				Name:    "John",
+				Address: Address{PostalCode: "12345"},
+				Billing: &Address{PostalCode: "54321"},
+			},
+		},
+		{
+			name: "nil pointer struct is skipped",
+			customer: Customer{
+				Base:    Base{ID: 1},
+				Name:    "John",
+				Address: Address{PostalCode: "12345"},
+			},
+		},
+		{
+			name: "nested struct field is invalid",
+			customer: Customer{
+				Base:    Base{ID: 1},
+				Name:    "John",
+				Address: Address{PostalCode: "123"},
+			},
+			expectedKeys: []string{"Address.PostalCode"},
+		},
+		{
+			name: "pointer to nested struct field is invalid",
+			customer: Customer{
+				Base:    Base{ID: 1},
+				Name:    "John",
+				Address: Address{PostalCode: "12345"},
+				Billing: &Address{PostalCode: "1"},
+			},
+			expectedKeys: []string{"Billing.PostalCode"},
+		},
+		{
+			name: "embedded struct fields are promoted",
+			customer: Customer{
+				Name:    "John",
+				Address: Address{PostalCode: "12345"},
+			},
+			expectedKeys: []string{"ID"},
+		},
+		{
+			name: "unexported nested struct is skipped",
+			customer: Customer{
+				Base:     Base{ID: 1},
+				Name:     "John",
+				Address:  Address{PostalCode: "12345"},
+				internal: Address{PostalCode: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.customer)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+// TestMapIsValidDeeplyNestedStructs tests paths through several levels of nesting
+func TestMapIsValidDeeplyNestedStructs(t *testing.T) {
+	type Node struct {
+		Value int `validation:"max=10"`
+		Next  *Node
+	}
+
+	root := Node{Value: 1, Next: &Node{Value: 2, Next: &Node{Value: 30}}}
+
+	ok, errs := IsValid(root)
+	assert.False(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Next.Next.Value", errs[0].Key)
+	assert.Equal(t, "Next.Next.Value must be less than or equal to 10", errs[0].Error())
+}
+
+// TestMapIsValidCycles tests values found again through a cycle are validated once on each path
+func TestMapIsValidCycles(t *testing.T) {
+	type Node struct {
+		Name     string `validation:"required"`
+		Parent   *Node
+		Child    *Node
+		Children []Node          `validation:"dive"`
+		Index    map[string]Node `validation:"dive"`
+	}
+
+	// A child pointing back at its parent
+	root := &Node{Name: "root"}
+	root.Child = &Node{Parent: root}
+	ok, errs := IsValid(root)
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{{Key: "Child.Name", Message: "is required"}}, withoutDetails(errs))
+
+	// The root passed by value is a copy, so the cycle is found one level deeper
+	_, errs = IsValid(*root)
+	assert.Equal(t, []ValidationError{{Key: "Child.Name", Message: "is required"}}, withoutDetails(errs))
+
+	// A slice holding itself, and a map holding itself
+	children := make([]Node, 1)
+	children[0].Children = children
+	_, errs = IsValid(Node{Name: "list", Children: children})
+	assert.Equal(t, []ValidationError{{Key: "Children[0].Name", Message: "is required"}}, withoutDetails(errs))
+
+	index := map[string]Node{}
+	index["self"] = Node{Index: index}
+	_, errs = IsValid(Node{Name: "index", Index: index})
+	assert.Equal(t, []ValidationError{{Key: "Index[self].Name", Message: "is required"}}, withoutDetails(errs))
+
+	// The same value in two places of the object is not a cycle
+	shared := &Node{}
+	_, errs = IsValid(Node{Name: "shared", Parent: shared, Child: shared})
+	assert.Equal(t, []ValidationError{
+		{Key: "Parent.Name", Message: "is required"},
+		{Key: "Child.Name", Message: "is required"},
+	}, withoutDetails(errs))
+}
+
+// TestMapIsValidDive tests validating the elements of slices, arrays and maps
+func TestMapIsValidDive(t *testing.T) {
+	type Line struct {
+		SKU string `validation:"min_length=3"`
+	}
+
+	type Invoice struct {
+		Emails   []string          `validation:"dive format=email"`
+		Codes    [2]string         `validation:"dive max_length=2"`
+		Limits   map[string]int    `validation:"dive keys min_length=3 endkeys min=0"`
+		Matrix   [][]int           `validation:"dive dive max=9"`
+		Lines    []Line            `validation:"dive"`
+		Pointers *[]string         `validation:"dive min_length=1"`
+		ByName   map[string]*Line  `validation:"dive"`
+		Labels   map[string]string `validation:"dive keys max_length=2 endkeys"`
+	}
+
+	valid := func() Invoice {
+		pointers := []string{"a"}
+		return Invoice{
+			Emails:   []string{"one@domain.com", "two@domain.com"},
+			Codes:    [2]string{"ab", "cd"},
+			Limits:   map[string]int{"gold": 10, "silver": 0},
+			Matrix:   [][]int{{1, 2}, {3}},
+			Lines:    []Line{{SKU: "abc"}},
+			Pointers: &pointers,
+			ByName:   map[string]*Line{"first": {SKU: "abc"}, "missing": nil},
+			Labels:   map[string]string{"en": "anything"},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		modify       func(invoice *Invoice)
+		expectedKeys []string
+	}{
+		{
+			name:   "all valid",
+			modify: func(_ *Invoice) {},
+		},
+		{
+			name: "invalid slice element",
+			modify: func(invoice *Invoice) {
+				invoice.Emails = append(invoice.Emails, "invalid")
+			},
+			expectedKeys: []string{"Emails[2]"},
+		},
+		{
+			name: "invalid array element",
+			modify: func(invoice *Invoice) {
+				invoice.Codes[1] = "abc"
+			},
+			expectedKeys: []string{"Codes[1]"},
+		},
+		{
+			name: "invalid map key and value",
+			modify: func(invoice *Invoice) {
+				invoice.Limits["gold"] = -1
+				invoice.Limits["ab"] = 1
+			},
+			expectedKeys: []string{"Limits[ab]", "Limits[gold]"},
+		},
+		{
+			name: "invalid element of a nested slice",
+			modify: func(invoice *Invoice) {
+				invoice.Matrix[1] = append(invoice.Matrix[1], 10)
+			},
+			expectedKeys: []string{"Matrix[1][1]"},
+		},
+		{
+			name: "invalid struct element",
+			modify: func(invoice *Invoice) {
+				invoice.Lines = append(invoice.Lines, Line{SKU: "a"})
+			},
+			expectedKeys: []string{"Lines[1].SKU"},
+		},
+		{
+			name: "invalid element behind a pointer",
+			modify: func(invoice *Invoice) {
+				*invoice.Pointers = append(*invoice.Pointers, "")
+			},
+			expectedKeys: []string{"Pointers[1]"},
+		},
+		{
+			name: "nil pointer collection is skipped",
+			modify: func(invoice *Invoice) {
+				invoice.Pointers = nil
+			},
+		},
+		{
+			name: "invalid struct pointer map value",
+			modify: func(invoice *Invoice) {
+				invoice.ByName["second"] = &Line{SKU: "b"}
+			},
+			expectedKeys: []string{"ByName[second].SKU"},
+		},
+		{
+			name: "invalid map key with no value rules",
+			modify: func(invoice *Invoice) {
+				invoice.Labels["english"] = "anything"
+			},
+			expectedKeys: []string{"Labels[english]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoice := valid()
+			tt.modify(&invoice)
+
+			ok, errs := IsValid(invoice)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+// TestMapIsValidDiveMapKeyOrder tests that map errors are reported in key order
+func TestMapIsValidDiveMapKeyOrder(t *testing.T) {
+	type Limits struct {
+		Values map[string]int `validation:"dive min=0"`
+	}
+
+	limits := Limits{Values: map[string]int{"c": -1, "a": -1, "b": -1}}
+	for i := 0; i < 10; i++ {
+		_, errs := IsValid(limits)
+		require.Len(t, errs, 3)
+		assert.Equal(t, "Values[a]", errs[0].Key)
+		assert.Equal(t, "Values[b]", errs[1].Key)
+		assert.Equal(t, "Values[c]", errs[2].Key)
+	}
+}
+
+// TestMapCompile tests that invalid tags are returned as compile errors
+func TestMapCompile(t *testing.T) {
+	type Valid struct {
+		Name   string   `validation:"min_length=1"`
+		Emails []string `validation:"dive format=email"`
+	}
+
+	type UnknownValidation struct {
+		Name string `validation:"min_length=1 not_a_validation=1"`
+	}
+
+	type InvalidSpecification struct {
+		Name string `validation:"min_length=1 format='regexp:^[a-z]+$"`
+	}
+
+	type BuilderError struct {
+		Name string `validation:"min_length=abc"`
+	}
+
+	type InvalidDive struct {
+		Name string `validation:"dive min_length=1"`
+	}
+
+	type InvalidKeys struct {
+		Values []string `validation:"dive keys min_length=1 endkeys"`
+	}
+
+	type MissingKeys struct {
+		Values map[string]int `validation:"dive endkeys"`
+	}
+
+	type MissingEndKeys struct {
+		Values map[string]int `validation:"dive keys min_length=1"`
+	}
+
+	type UnknownOption struct {
+		Name string `validation:"min_length=1;group=create"`
+	}
+
+	type MissingGroupNames struct {
+		Name string `validation:"min_length=1;groups"`
+	}
+
+	type ModifierOption struct {
+		Values []string `validation:"dive;groups=create min_length=1"`
+	}
+
+	type InvalidGroupRule struct {
+		Name string `validation:"min_length=1 not_a_validation=1;groups=admin"`
+	}
+
+	type Child struct {
+		Name string `validation:"unknown=1"`
+	}
+
+	type InvalidNested struct {
+		Children []Child `validation:"dive"`
+	}
+
+	type Recursive struct {
+		Name string `validation:"min_length=1"`
+		Next *Recursive
+	}
+
+	tests := []struct {
+		name          string
+		objectType    reflect.Type
+		expectedError error
+		expectedField string
+		expectedTag   string
+	}{
+		{"valid", reflect.TypeOf(Valid{}), nil, "", ""},
+		{"valid pointer", reflect.TypeOf(&Valid{}), nil, "", ""},
+		{"recursive type", reflect.TypeOf(Recursive{}), nil, "", ""},
+		{"unknown validation", reflect.TypeOf(UnknownValidation{}), ErrUnknownValidation, "Name", "not_a_validation=1"},
+		{"invalid specification", reflect.TypeOf(InvalidSpecification{}), ErrInvalidSpecification, "Name", "min_length=1 format='regexp:^[a-z]+$"},
+		{"invalid dive", reflect.TypeOf(InvalidDive{}), ErrInvalidDive, "Name", "dive"},
+		{"invalid keys", reflect.TypeOf(InvalidKeys{}), ErrInvalidKeys, "Values", "keys"},
+		{"missing keys", reflect.TypeOf(MissingKeys{}), ErrMissingKeys, "Values", "endkeys"},
+		{"missing endkeys", reflect.TypeOf(MissingEndKeys{}), ErrMissingEndKeys, "Values", "dive keys min_length=1"},
+		{"unknown option", reflect.TypeOf(UnknownOption{}), ErrUnknownOption, "Name", "min_length=1;group=create"},
+		{"missing group names", reflect.TypeOf(MissingGroupNames{}), ErrGroupNameRequired, "Name", "min_length=1;groups"},
+		{"modifier with option", reflect.TypeOf(ModifierOption{}), ErrModifierOptions, "Values", "dive;groups=create"},
+		{"invalid rule of an inactive group", reflect.TypeOf(InvalidGroupRule{}), ErrUnknownValidation, "Name", "not_a_validation=1;groups=admin"},
+		{"invalid nested type", reflect.TypeOf(InvalidNested{}), ErrUnknownValidation, "Name", "unknown=1"},
+		{"not a struct", reflect.TypeOf(1), ErrNotStruct, "", ""},
+		{"nil type", nil, ErrNotStruct, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Compile(tt.objectType)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.expectedError)
+			var compileErr *CompileError
+			require.ErrorAs(t, err, &compileErr)
+			assert.Equal(t, tt.expectedField, compileErr.Field)
+			assert.Equal(t, tt.expectedTag, compileErr.Tag)
+		})
+	}
+
+	t.Run("builder error", func(t *testing.T) {
+		err := Compile(reflect.TypeOf(BuilderError{}))
+		var compileErr *CompileError
+		require.ErrorAs(t, err, &compileErr)
+		assert.Equal(t, "Name", compileErr.Field)
+		assert.Equal(t, "min_length=abc", compileErr.Tag)
+		assert.Contains(t, compileErr.Struct, "BuilderError")
+	})
+}
+
+// TestMapRegister tests compiling the types of several values at once
+func TestMapRegister(t *testing.T) {
+	type Valid struct {
+		Name string `validation:"min_length=1"`
+	}
+
+	type Invalid struct {
+		Name string `validation:"unknown=1"`
+	}
+
+	require.NoError(t, Register(Valid{}, &Valid{}))
+	require.ErrorIs(t, Register(Valid{}, Invalid{}), ErrUnknownValidation)
+	require.ErrorIs(t, Register(nil), ErrNotStruct)
+}
+
+// TestNewMap tests maps created with the built-in validations are independent of each other
+func TestNewMap(t *testing.T) {
+	type Tenant struct {
+		Name string `validation:"required min_length=2"`
+		Code string `validation:"tenant_code"`
+	}
+
+	first, second := NewMap(), NewMap()
+	assert.Subset(t, first.List(), []string{"compare", "eq_field", "format", "max", "min", "one_of", "required"})
+	assert.Equal(t, first.List(), second.List())
+
+	// Validations added to a map are not known to the others
+	first.AddRuleValidation("tenant_code", requiredRuleValidation)
+	assert.Contains(t, first.List(), "tenant_code")
+	assert.NotContains(t, second.List(), "tenant_code")
+	assert.NotContains(t, List(), "tenant_code")
+
+	// Pointers are validated with the map as well
+	ok, errs := first.IsValid(&Tenant{Name: "A"})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "must be at least 2 characters"},
+		{Key: "Code", Message: "is required"},
+	}, withoutDetails(errs))
+
+	_, errs = second.IsValid(&Tenant{Name: "Acme"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Code", errs[0].Key)
+	assert.Contains(t, errs[0].Message, "unknown validation named: tenant_code")
+}
+
+// TestMapUnregister tests the plans using a removed validation are rebuilt
+func TestMapUnregister(t *testing.T) {
+	type Account struct {
+		Name string `validation:"min_length=2"`
+	}
+
+	m := NewMap()
+	ok, _ := m.IsValid(Account{Name: "Al"})
+	require.True(t, ok)
+
+	m.Unregister("min_length")
+	assert.NotContains(t, m.List(), "min_length")
+	require.ErrorIs(t, m.Compile(reflect.TypeOf(Account{})), ErrUnknownValidation)
+
+	// Registering the validation again rebuilds the plans as well
+	m.AddValidation("min_length", minLengthValidation)
+	ok, errs := m.IsValid(Account{Name: "A"})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{{Key: "Name", Message: "must be at least 2 characters"}}, withoutDetails(errs))
+}
+
+// TestMapClone tests changing a clone does not change the map it was cloned from
+func TestMapClone(t *testing.T) {
+	type Customer struct {
+		Name  string
+		Email string `validation:"max_length=10"`
+	}
+
+	m := NewMap()
+	m.AddOpenAPIExtension("max_length", func(Rule) map[string]interface{} {
+		return map[string]interface{}{"x-short": true}
+	})
+	require.NoError(t, m.Rules(Customer{}).Field("Name", "required").Register())
+
+	clone := m.Clone()
+	assert.Equal(t, m.List(), clone.List())
+	_, errs := clone.IsValid(Customer{Email: "someone@example.com"})
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "is required"},
+		{Key: "Email", Message: "must be no more than 10 characters"},
+	}, withoutDetails(errs))
+
+	schemas, err := clone.OpenAPISchemas(reflect.TypeOf(Customer{}))
+	require.NoError(t, err)
+	assert.Contains(t, fmt.Sprint(schemas), "x-short")
+
+	// Changes to the clone stay in the clone
+	require.NoError(t, clone.Rules(Customer{}).Field("Email", "required").Register())
+	clone.Unregister("max_length")
+	assert.Contains(t, m.List(), "max_length")
+	_, errs = m.IsValid(Customer{})
+	assert.Equal(t, []ValidationError{{Key: "Name", Message: "is required"}}, withoutDetails(errs))
+	_, errs = m.IsValid(Customer{Name: "Al", Email: "someone@example.com"})
+	assert.Equal(t, []ValidationError{{Key: "Email", Message: "must be no more than 10 characters"}}, withoutDetails(errs))
+}
+
+// TestMapIsValidErrorDetails tests the codes, parameters, values and sentinel errors of the errors,
+// which are reported in the order the fields are declared
+func TestMapIsValidErrorDetails(t *testing.T) {
+	type Status string
+
+	type Signup struct {
+		Name     string   `validation:"required"`
+		Email    *string  `validation:"format=email"`
+		Status   Status   `validation:"one_of=new,active"`
+		Age      int      `validation:"min=18"`
+		Password string   `validation:"min_length=8;redact"`
+		Tags     []string `validation:"dive max_length=3"`
+	}
+
+	email := "invalid"
+	ok, errs := IsValid(&Signup{Email: &email, Status: "gone", Age: 12, Password: "secret", Tags: []string{"a", "long"}})
+	assert.False(t, ok)
+	assert.Equal(t, []ValidationError{
+		{Key: "Name", Message: "is required", Code: "required", Value: ""},
+		{
+			Key: "Email", Message: "does not match email format", Code: "format", Params: []string{"email"},
+			Value: "invalid", Err: ErrEmailFormatInvalid,
+		},
+		{
+			Key: "Status", Message: "must be one of new, active", Code: "one_of", Params: []string{"new", "active"},
+			Value: Status("gone"), Err: ErrEnumValueNotAllowed,
+		},
+		{Key: "Age", Message: "must be greater than or equal to 18", Code: "min", Params: []string{"18"}, Value: 12},
+		{Key: "Password", Message: "must be at least 8 characters", Code: "min_length", Params: []string{"8"}},
+		{Key: "Tags[1]", Message: "must be no more than 3 characters", Code: "max_length", Params: []string{"3"}, Value: "long"},
+	}, errs)
+
+	require.ErrorIs(t, ValidationErrors(errs), ErrEmailFormatInvalid)
+	assert.Equal(t, []string{"Tags", "1"}, errs[5].Path())
+	assert.Len(t, ValidationErrors(errs).Lookup("Tags"), 1)
+
+	// The parameters are copies, changing them does not change the rules
+	errs[3].Params[0] = "21"
+	_, errs = IsValid(&Signup{Name: "Al", Status: "new", Age: 12, Password: "password"})
+	assert.Equal(t, []string{"18"}, errs[0].Params)
+
+	// Errors of no rule have no code
+	_, errs = IsValid((*Signup)(nil))
+	assert.Equal(t, []ValidationError{{Message: ErrNotStruct.Error(), Err: ErrNotStruct}}, errs)
+}
+
+// TestMapIsValidCompileError tests that invalid tags are reported by IsValid instead of exiting
+func TestMapIsValidCompileError(t *testing.T) {
+	type Child struct {
+		Name string `validation:"unknown=1"`
+	}
+
+	type Parent struct {
+		Age   int `validation:"min=1"`
+		Child Child
+	}
+
+	type Invalid struct {
+		Name string `validation:"min_length=abc"`
+	}
+
+	t.Run("invalid tag", func(t *testing.T) {
+		ok, errs := IsValid(Invalid{})
+		assert.False(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Name", errs[0].Key)
+		assert.Contains(t, errs[0].Message, "min_length")
+	})
+
+	t.Run("invalid tag in nested struct", func(t *testing.T) {
+		ok, errs := IsValid(Parent{Age: 1})
+		assert.False(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Child.Name", errs[0].Key)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		ok, errs := IsValid(10)
+		assert.False(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, ErrNotStruct.Error(), errs[0].Message)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		ok, errs := IsValid(nil)
+		assert.False(t, ok)
+		require.Len(t, errs, 1)
+	})
+}
+
+// TestMapIsValidPointerFields tests validating the values of pointer fields, with nil pointers being absent
+func TestMapIsValidPointerFields(t *testing.T) {
+	type Patch struct {
+		Age      *uint     `validation:"min=18 max=120"`
+		Nickname *string   `validation:"min_length=3"`
+		Email    **string  `validation:"format=email"`
+		Score    *float64  `validation:"omitempty max=10"`
+		Name     *string   `validation:"required min_length=2"`
+		Tags     *[]string `validation:"omitempty dive min_length=2"`
+		Codes    []*string `validation:"dive required max_length=2"`
+	}
+
+	uintPtr := func(v uint) *uint { return &v }
+	stringPtr := func(v string) *string { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		patch        Patch
+		expectedKeys []string
+	}{
+		{
+			name:         "nil pointers are absent, only required runs",
+			patch:        Patch{},
+			expectedKeys: []string{"Name"},
+		},
+		{
+			name: "valid values behind pointers",
+			patch: Patch{
+				Age:      uintPtr(30),
+				Nickname: stringPtr("Johnny"),
+				Email: func() **string {
+					email := stringPtr("john@domain.com")
+					return &email
+				}(),
+				Score: floatPtr(5),
+				Name:  stringPtr("John"),
+				Tags:  &[]string{"ab"},
+				Codes: []*string{stringPtr("ab")},
+			},
+		},
+		{
+			name: "invalid values behind pointers",
+			patch: Patch{
+				Age:      uintPtr(10),
+				Nickname: stringPtr(""),
+				Email: func() **string {
+					email := stringPtr("invalid")
+					return &email
+				}(),
+				Score: floatPtr(11),
+				Name:  stringPtr("J"),
+				Tags:  &[]string{"a"},
+				Codes: []*string{stringPtr("abc"), nil},
+			},
+			expectedKeys: []string{"Age", "Nickname", "Email", "Score", "Name", "Tags[0]", "Codes[0]", "Codes[1]"},
+		},
+		{
+			name: "nil at the second level of indirection is absent",
+			patch: Patch{
+				Email: new(*string),
+				Name:  stringPtr("John"),
+			},
+		},
+		{
+			name: "a pointer to an empty value is present",
+			patch: Patch{
+				Name: stringPtr(""),
+			},
+			expectedKeys: []string{"Name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.patch)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+// TestMapIsValidGroups tests rules limited to groups only run when one of their groups is active
+func TestMapIsValidGroups(t *testing.T) {
+	type Address struct {
+		Street string `validation:"required;groups=create"`
+	}
+
+	type Account struct {
+		ID       uint64   `validation:"required;groups=update"`
+		Email    string   `validation:"format=email"`
+		Password string   `validation:"required;groups=create omitempty min_length=8"`
+		Role     string   `validation:"omitempty format='regexp:^(admin|user)$';groups=admin"`
+		Tags     []string `validation:"dive min_length=2;groups=create,update"`
+		Address  Address
+	}
+
+	valid := Account{Email: "john@domain.com"}
+	invalid := Account{Email: "john@domain.com", Password: "short", Role: "owner", Tags: []string{"a"}}
+
+	tests := []struct {
+		name         string
+		account      Account
+		groups       []string
+		expectedKeys []string
+	}{
+		{"no groups only runs rules in no group", valid, nil, nil},
+		{"no groups skips grouped rules", invalid, nil, []string{"Password"}},
+		{"create", valid, []string{"create"}, []string{"Password", "Address.Street"}},
+		{"create with invalid values", invalid, []string{"create"}, []string{"Password", "Tags[0]", "Address.Street"}},
+		{"update", valid, []string{"update"}, []string{"ID"}},
+		{"update and admin", invalid, []string{"update", "admin"}, []string{"ID", "Password", "Role", "Tags[0]"}},
+		{"repeated groups in any order", invalid, []string{"admin", "update", "admin"}, []string{"ID", "Password", "Role", "Tags[0]"}},
+		{"unknown group", invalid, []string{"other"}, []string{"Password"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValidGroups(&tt.account, tt.groups...)
+			assert.Equal(t, len(tt.expectedKeys) == 0, ok)
+
+			keys := make([]string, 0, len(errs))
+			for _, err := range errs {
+				keys = append(keys, err.Key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+
+	t.Run("plans are stored per group set", func(t *testing.T) {
+		accountType := reflect.TypeOf(Account{})
+		_, _ = IsValidGroups(invalid, "update", "admin")
+		_, _ = IsValidGroups(invalid, "admin", "update")
+
+		assert.NotNil(t, storedPlan(&DefaultMap, accountType, "admin,update"))
+		assert.Nil(t, storedPlan(&DefaultMap, accountType, "update,admin"))
+	})
+}
+
+// TestGroupsKey tests the normalization of the active groups
+func TestGroupsKey(t *testing.T) {
+	assert.Empty(t, groupsKey(nil))
+	assert.Equal(t, "create", groupsKey([]string{"create"}))
+	assert.Equal(t, "admin,update", groupsKey([]string{"update", "admin", "update"}))
+}
+
+// dateRange validates itself through a value receiver
+type dateRange struct {
+	Start int `validation:"min=0"`
+	End   int
+}
+
+// ValidateStruct checks the range does not end before it starts
+func (d dateRange) ValidateStruct() []ValidationError {
+	if d.End < d.Start {
+		return []ValidationError{{Key: "End", Message: "must not be before Start"}}
+	}
+	return nil
+}
+
+// booking validates itself through a pointer receiver, reporting an error for the struct itself
+type booking struct {
+	Guests   int `validation:"min=1"`
+	Rooms    int
+	Stay     dateRange
+	Extras   []dateRange          `validation:"dive"`
+	Seasons  map[string]dateRange `validation:"dive"`
+	Previous *booking
+}
+
+// ValidateStruct checks there are enough guests for the rooms
+func (b *booking) ValidateStruct() []ValidationError {
+	if b.Rooms > b.Guests {
+		return []ValidationError{{Message: "has more rooms than guests"}}
+	}
+	return nil
+}
+
+// TestMapIsValidStructValidator tests the errors of StructValidator are merged with the tag validations
+func TestMapIsValidStructValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		booking  booking
+		expected []ValidationError
+	}{
+		{
+			name:    "valid",
+			booking: booking{Guests: 2, Rooms: 1, Stay: dateRange{Start: 1, End: 2}},
+		},
+		{
+			name:    "struct error with an empty key and tag error",
+			booking: booking{Guests: 0, Rooms: 1},
+			expected: []ValidationError{
+				{Key: "Guests", Message: "must be greater than or equal to 1"},
+				{Key: "", Message: "has more rooms than guests"},
+			},
+		},
+		{
+			name: "nested, dived and map elements are prefixed",
+			booking: booking{
+				Guests:   1,
+				Stay:     dateRange{Start: 2, End: 1},
+				Extras:   []dateRange{{Start: 1, End: 1}, {Start: -1, End: -2}},
+				Seasons:  map[string]dateRange{"summer": {Start: 5, End: 4}},
+				Previous: &booking{Guests: 1, Rooms: 2},
+			},
+			expected: []ValidationError{
+				{Key: "Previous", Message: "has more rooms than guests"},
+				{Key: "Seasons[summer].End", Message: "must not be before Start"},
+				{Key: "Extras[1].Start", Message: "must be greater than or equal to 0"},
+				{Key: "Extras[1].End", Message: "must not be before Start"},
+				{Key: "Stay.End", Message: "must not be before Start"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.booking)
+			assert.Equal(t, len(tt.expected) == 0, ok)
+			assert.ElementsMatch(t, tt.expected, withoutDetails(errs))
+
+			// The pointer receiver is found through a pointer as well
+			ok, errs = IsValid(&tt.booking)
+			assert.Equal(t, len(tt.expected) == 0, ok)
+			assert.ElementsMatch(t, tt.expected, withoutDetails(errs))
+		})
+	}
+}
+
+// allocationAddress is a nested struct of allocationOrder
+type allocationAddress struct {
+	City string `validation:"required min_length=2"`
+	Zip  string `validation:"format=regexp:^[0-9]{5}$"`
+}
+
+// allocationOrder is a struct with the common validations, to count the allocations of IsValid
+type allocationOrder struct {
+	ID       uint64              `validation:"min=1"`
+	Name     string              `validation:"required max_length=20"`
+	Age      int                 `validation:"min=18 max=130"`
+	Score    float64             `validation:"max=10"`
+	Status   string              `validation:"one_of=open,closed"`
+	Nick     *string             `validation:"omitempty min_length=2"`
+	Address  allocationAddress   `validation:"required"`
+	Previous []allocationAddress `validation:"dive"`
+	Tags     []string            `validation:"dive min_length=1"`
+}
+
+// newAllocationOrder creates a valid allocationOrder
+func newAllocationOrder() *allocationOrder {
+	nick := "Al"
+	return &allocationOrder{
+		ID: 1, Name: "Bob", Age: 30, Score: 2.5, Status: "open", Nick: &nick,
+		Address:  allocationAddress{City: "NY", Zip: "10001"},
+		Previous: []allocationAddress{{City: "LA", Zip: "90001"}},
+		Tags:     []string{"new", "gift"},
+	}
+}
+
+// TestMapIsValidAllocations tests validating a valid object does not allocate
+func TestMapIsValidAllocations(t *testing.T) {
+	order := newAllocationOrder()
+	ok, errs := IsValid(order)
+	require.True(t, ok, errs)
+
+	if !raceEnabled {
+		assert.Zero(t, testing.AllocsPerRun(100, func() {
+			_, _ = IsValid(order)
+		}))
+	}
+
+	// Invalid objects only allocate for their errors, whose keys are built from the paths
+	order.Previous[0].City = ""
+	order.Tags[1] = ""
+	_, errs = IsValid(order)
+	assert.Equal(t, []ValidationError{
+		{Key: "Previous[0].City", Message: "is required", Code: "required", Value: ""},
+		{Key: "Previous[0].City", Message: "must be at least 2 characters", Code: "min_length", Params: []string{"2"}, Value: ""},
+		{Key: "Tags[1]", Message: "must be at least 1 characters", Code: "min_length", Params: []string{"1"}, Value: ""},
+	}, errs)
+}
+
+// TestMapIsValidRuleAllocations tests validating a valid object does not allocate with the rules
+// reading other fields, times and dives over slices
+func TestMapIsValidRuleAllocations(t *testing.T) {
+	type Range struct {
+		Min int
+		Max int `validation:"gte_field=Min"`
+	}
+	type Address struct {
+		Country string
+		State   string `validation:"required_if=Country,US"`
+	}
+	type Stay struct {
+		Start time.Time `validation:"required"`
+		End   time.Time `validation:"gt_field=Start"`
+	}
+	type Tags struct {
+		Names  []string `validation:"dive min_length=1"`
+		Scores []int    `validation:"dive min=0"`
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		object interface{}
+	}{
+		{"gte_field", &Range{Min: 1, Max: 2}},
+		{"required_if", &Address{Country: "US", State: "NY"}},
+		{"gt_field on time.Time", &Stay{Start: start, End: start.Add(time.Hour)}},
+		{"dive over a slice", &Tags{Names: []string{"a", "b"}, Scores: []int{1, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, errs := IsValid(tt.object)
+			require.True(t, ok, errs)
+
+			if !raceEnabled {
+				assert.Zero(t, testing.AllocsPerRun(100, func() {
+					_, _ = IsValid(tt.object)
+				}))
+			}
+		})
+	}
+}
+
+// BenchmarkMapIsValidNested benchmarks validating a valid object with nested structs and dives
+func BenchmarkMapIsValidNested(b *testing.B) {
+	order := newAllocationOrder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = IsValid(order)
+	}
+}
+
+// BenchmarkMapIsValidParallel benchmarks validating a type from several goroutines
+func BenchmarkMapIsValidParallel(b *testing.B) {
+	order := newAllocationOrder()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = IsValid(order)
+		}
+	})
+}
+
+// TestRunValidation tests validations without ValidateValue receive boxed values
+func TestRunValidation(t *testing.T) {
+	validation := &setValidation[int]{allowed: NewSet(0)}
+	validation.SetFieldName("Value")
+
+	var held interface{} = 0
+	assert.Nil(t, runValidation(validation, reflect.ValueOf(&held).Elem(), reflect.Value{}))
+	assert.Nil(t, runValidation(&requiredValidation{}, reflect.ValueOf(1), reflect.Value{}))
+	require.NotNil(t, runValidation(validation, reflect.Value{}, reflect.Value{}))
+
+	// The value an interface holds is validated, as when it is boxed
+	required := &requiredValidation{}
+	assert.NotNil(t, runValidation(required, reflect.ValueOf(&held).Elem(), reflect.Value{}))
+	assert.Equal(t, required.Validate(held, reflect.Value{}),
+		runValidation(required, reflect.ValueOf(&held).Elem(), reflect.Value{}))
+}
+
 // Tests that are still needed for full package coverage
 // todo:  TestMap_AddValidation(t *testing.T)
 // todo:  TestMap_IsValid(t *testing.T)
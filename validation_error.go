@@ -1,21 +1,132 @@
 package validate
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
-// ValidationError is the key and message of the corresponding error
+// ValidationError is the key and message of the corresponding error, with the rule that failed
 type ValidationError struct {
-	// Key is the Field name, key name
+	// Key is the Field name, key name (the full path of the value, e.g. "Items[2].Name")
 	Key string
 
+	// Label is the label of the field from its label tag (e.g. "Date of birth"), if any
+	Label string
+
 	// Message is the error message
 	Message string
+
+	// Code is the stable name of the rule that failed (e.g. "min_length"), empty for errors of no rule
+	Code string
+
+	// Params are the parameters of the rule (e.g. ["3"] for min_length=3)
+	Params []string
+
+	// Value is the offending value, nil when the rule has the redact option. It can hold secrets
+	// (e.g. a password), see ValidationErrors.Redact before logging or returning the errors.
+	Value interface{}
+
+	// Err is the sentinel error of the failure (e.g. ErrEmailFormatInvalid), if any
+	Err error
+
+	// messages are the message templates of the rule's message options by locale ("" for the default)
+	messages map[string]string
 }
 
-// ValidationError returns a string of a key + a message
+// ValidationError returns a string of a key (or the label) + a message
 func (v *ValidationError) Error() string {
+	if len(v.Label) > 0 {
+		return v.Label + " " + v.Message
+	}
 	return v.Key + " " + v.Message
 }
 
+// Unwrap returns the sentinel error so errors.Is and errors.As can be used
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// Path returns the segments of the key, the field names, indexes and map keys
+// (e.g. ["Items", "2", "Name"] for "Items[2].Name")
+func (v *ValidationError) Path() []string {
+	return splitPath(v.Key)
+}
+
+// describe sets the code, parameters, value and label of an error of the rule (nil for validations
+// created in code), keeping those the validation set itself. The label is only set for errors about
+// the field, and the message is replaced by the rule's message option.
+func (v *ValidationError) describe(rule *Rule, field, label string, value reflect.Value) {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if v.Key == field && len(v.Label) == 0 {
+		v.Label = label
+	}
+
+	if rule != nil {
+		if len(v.Code) == 0 {
+			v.Code = rule.Name
+		}
+		if v.Params == nil && len(rule.Params) > 0 {
+			v.Params = append([]string(nil), rule.Params...)
+		}
+		if _, redacted := rule.Options[redactOption]; redacted {
+			value = reflect.Value{}
+			v.Value = nil
+		}
+		v.messages = messageOptions(rule)
+	}
+
+	if v.Value == nil && value.IsValid() && value.CanInterface() {
+		v.Value = value.Interface()
+	}
+	if template, ok := v.messages[""]; ok {
+		v.Message = renderMessage(template, v)
+	}
+}
+
+// splitPath splits a key into its segments, the content of brackets being a single segment
+func splitPath(key string) []string {
+	var segments []string
+	for len(key) > 0 {
+		switch key[0] {
+		case '.':
+			key = key[1:]
+			continue
+		case '[':
+			// The key ends at the bracket closing the segment, map keys may hold brackets
+			end := closingBracket(key)
+			segments = append(segments, key[1:end])
+			if end == len(key) {
+				return segments
+			}
+			key = key[end+1:]
+			continue
+		}
+
+		end := strings.IndexAny(key, ".[")
+		if end < 0 {
+			end = len(key)
+		}
+		segments = append(segments, key[:end])
+		key = key[end:]
+	}
+	return segments
+}
+
+// closingBracket returns the index of the bracket closing the segment at the start of the key,
+// followed by another segment or the end of the key (the end of the key when it is not closed)
+func closingBracket(key string) int {
+	for i := 1; i < len(key); i++ {
+		if key[i] == ']' && (i == len(key)-1 || key[i+1] == '.' || key[i+1] == '[') {
+			return i
+		}
+	}
+	return len(key)
+}
+
 // ValidationErrors is a slice of validation errors
 type ValidationErrors []ValidationError
 
@@ -36,3 +147,60 @@ func (v ValidationErrors) Error() (errors string) {
 
 	return errors
 }
+
+// Redact returns copies of the errors without their offending values, to log them or return them
+// to clients without leaking the values (see the redact option to leave the values of a rule out)
+func (v ValidationErrors) Redact() ValidationErrors {
+	if v == nil {
+		return nil
+	}
+
+	redacted := make(ValidationErrors, len(v))
+	copy(redacted, v)
+	for i := range redacted {
+		redacted[i].Value = nil
+	}
+	return redacted
+}
+
+// GroupByField groups the errors by their key, keeping their order for each key
+func (v ValidationErrors) GroupByField() map[string]ValidationErrors {
+	fields := make(map[string]ValidationErrors)
+	for _, err := range v {
+		fields[err.Key] = append(fields[err.Key], err)
+	}
+	return fields
+}
+
+// Lookup returns the errors of the value at the path (e.g. "Items[2]"), including the errors
+// of the values it holds (e.g. "Items[2].Name")
+func (v ValidationErrors) Lookup(path string) ValidationErrors {
+	var found ValidationErrors
+	for _, err := range v {
+		if err.Key == path || len(path) == 0 ||
+			strings.HasPrefix(err.Key, path) && (err.Key[len(path)] == '.' || err.Key[len(path)] == '[') {
+			found = append(found, err)
+		}
+	}
+	return found
+}
+
+// Is determines if one of the errors is the target, so errors.Is finds sentinel errors
+func (v ValidationErrors) Is(target error) bool {
+	for i := range v {
+		if errors.Is(&v[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors matching the target, so errors.As can be used
+func (v ValidationErrors) As(target interface{}) bool {
+	for i := range v {
+		if errors.As(&v[i], target) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidationErrorsErrorNoErrors(t *testing.T) {
@@ -16,3 +17,111 @@ func TestValidationErrorsErrorNoErrors(t *testing.T) {
 	// Assert that the result is an empty string
 	assert.Empty(t, result)
 }
+
+// TestValidationErrorPath tests splitting keys into their segments
+func TestValidationErrorPath(t *testing.T) {
+	tests := map[string][]string{
+		"":                      nil,
+		"Name":                  {"Name"},
+		"Items[2].Name":         {"Items", "2", "Name"},
+		"Matrix[1][0]":          {"Matrix", "1", "0"},
+		"Tags[a.b].Value":       {"Tags", "a.b", "Value"},
+		"Tags[[x]].Value":       {"Tags", "[x]", "Value"},
+		"[3].City":              {"3", "City"},
+		"Seasons[summer].Start": {"Seasons", "summer", "Start"},
+		"Open[key":              {"Open", "key"},
+	}
+	for key, expected := range tests {
+		err := ValidationError{Key: key}
+		assert.Equal(t, expected, err.Path(), key)
+	}
+}
+
+// TestValidationErrorUnwrap tests finding the sentinel errors of validation errors
+func TestValidationErrorUnwrap(t *testing.T) {
+	err := &ValidationError{Key: "Email", Message: "does not match email format", Err: ErrEmailFormatInvalid}
+	require.ErrorIs(t, err, ErrEmailFormatInvalid)
+	require.NotErrorIs(t, &ValidationError{Key: "Email"}, ErrEmailFormatInvalid)
+
+	errs := ValidationErrors{{Key: "Name", Message: "is required"}, *err}
+	require.ErrorIs(t, errs, ErrEmailFormatInvalid)
+	require.NotErrorIs(t, errs, ErrEnumValueNotAllowed)
+
+	var found *ValidationError
+	require.ErrorAs(t, errs, &found)
+	assert.Equal(t, "Name", found.Key)
+
+	// Compile errors are wrapped by the errors reporting them
+	type Invalid struct {
+		Name string `validation:"unknown_rule"`
+	}
+	_, validationErrors := IsValid(Invalid{})
+	var compileErr *CompileError
+	require.ErrorAs(t, ValidationErrors(validationErrors), &compileErr)
+	assert.Equal(t, "Name", compileErr.Field)
+	require.ErrorIs(t, ValidationErrors(validationErrors), ErrUnknownValidation)
+}
+
+// TestValidationErrorsGroupByField tests grouping the errors by their keys
+func TestValidationErrorsGroupByField(t *testing.T) {
+	errs := ValidationErrors{
+		{Key: "Name", Message: "is required"},
+		{Key: "Items[0]", Message: "is required"},
+		{Key: "Name", Message: "must be at least 2 characters"},
+	}
+	assert.Equal(t, map[string]ValidationErrors{
+		"Name":     {errs[0], errs[2]},
+		"Items[0]": {errs[1]},
+	}, errs.GroupByField())
+	assert.Empty(t, ValidationErrors(nil).GroupByField())
+}
+
+// TestValidationErrorsRedact tests leaving the offending values out of copies of the errors
+func TestValidationErrorsRedact(t *testing.T) {
+	type Login struct {
+		Email    string `validation:"format=email"`
+		Password string `validation:"min_length=8"`
+	}
+	_, errs := IsValid(Login{Email: "invalid", Password: "secret"})
+	require.Len(t, errs, 2)
+
+	redacted := ValidationErrors(errs).Redact()
+	assert.Nil(t, redacted[0].Value)
+	assert.Nil(t, redacted[1].Value)
+	assert.Equal(t, "min_length", redacted[1].Code)
+	assert.Equal(t, errs[1].Error(), redacted[1].Error())
+	require.ErrorIs(t, redacted, ErrEmailFormatInvalid)
+
+	// The errors are not changed
+	assert.Equal(t, "secret", errs[1].Value)
+	assert.Nil(t, ValidationErrors(nil).Redact())
+}
+
+// TestValidationErrorsLookup tests finding the errors of a value and the values it holds
+func TestValidationErrorsLookup(t *testing.T) {
+	errs := ValidationErrors{
+		{Key: "Items", Message: "is required"},
+		{Key: "Items[0].Name", Message: "is required"},
+		{Key: "Items[1]", Message: "is required"},
+		{Key: "ItemsCount", Message: "must be greater than or equal to 1"},
+		{Key: "Address.City", Message: "is required"},
+	}
+	assert.Equal(t, ValidationErrors{errs[0], errs[1], errs[2]}, errs.Lookup("Items"))
+	assert.Equal(t, ValidationErrors{errs[1]}, errs.Lookup("Items[0]"))
+	assert.Equal(t, ValidationErrors{errs[4]}, errs.Lookup("Address.City"))
+	assert.Equal(t, errs, errs.Lookup(""))
+	assert.Nil(t, errs.Lookup("Address.Zip"))
+}
+
+// withoutDetails strips the codes, parameters, values and sentinel errors of the errors, for the
+// tests comparing their keys and messages
+func withoutDetails(errs []ValidationError) []ValidationError {
+	if errs == nil {
+		return nil
+	}
+	stripped := make([]ValidationError, len(errs))
+	for i, err := range errs {
+		stripped[i] = ValidationError{Key: err.Key, Message: err.Message}
+	}
+	return stripped
+}